@@ -1,30 +1,95 @@
 package config
 
 import (
+	"go-task-easy-list/internal/auth/domain/model"
 	"os"
 
 	"github.com/joho/godotenv"
 )
 
+// EnvProduction - Valor de APP_ENV con el que se exigen secretos y claves propios
+const EnvProduction = "production"
+
+// defaultJWTSecret - Secreto de ejemplo; no se admite en producción
+const defaultJWTSecret = "super-secret-key"
+
 type Config struct {
 	Port                 string
+	AppEnv               string // development o production
 	DBPath               string
 	// DatabaseUrl       string    // para base de datos Postgres
-	JWTSecret            string
-	JWTAccessExpiration  string
-	JWTRefreshExpiration string
+	JWTSecret            string // secreto base de REFRESH_TOKEN_SECRET y CURSOR_SECRET
+	JWTKeysDir           string // directorio con las claves de firma de los JWT (<kid>.pem)
+	JWTActiveKID         string // kid de la clave que firma los tokens nuevos
+	JWTAccessExpiration  string // duración del access token ("15m", "1h")
+	JWTRefreshExpiration string // duración de la sesión / refresh token ("7d")
+	MaxSessionsPerUser   string // sesiones simultáneas por usuario (0 = sin límite)
+	SessionLimitPolicy   string // al superar el límite: evict_oldest o reject
+	RefreshTokenSecret   string // clave del HMAC con el que se guardan los refresh tokens
+	CursorSecret         string // firma de los cursores de paginación
+	TaskStatusWorkflow   string // transiciones de estado permitidas ("ORIGEN>DESTINO,...")
+	TaskTrashRetention   string // tiempo que pasan las tareas eliminadas en la papelera ("30d", "72h")
+	IdempotencyTTL       string // tiempo que se guarda la respuesta de cada Idempotency-Key ("24h")
+
+	// Recordatorios
+	ReminderInterval string // cada cuánto revisa el scheduler los recordatorios vencidos
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPFrom         string
+	WebhookURL       string
+	WebhookSecret    string
 }
 
 func LoadConfig() (*Config, error) {
 	godotenv.Load()
 
-	return &Config{
+	jwtSecret := getEnv("JWT_SECRET", defaultJWTSecret)
+
+	cfg := &Config{
 		Port: getEnv("PORT", "8080"),
+		AppEnv: getEnv("APP_ENV", "development"),
 		// DatabaseUrl: getEnv("DATABASE_URL", ""), // para base de datos Postgres
-		JWTSecret: getEnv("JWT_SECRET", "super-secret-key"),
+		JWTSecret: jwtSecret,
+		// Sin directorio se genera una clave temporal (solo fuera de producción)
+		JWTKeysDir: getEnv("JWT_KEYS_DIR", ""),
+		JWTActiveKID: getEnv("JWT_ACTIVE_KID", ""),
 		JWTAccessExpiration: getEnv("JWT_ACCESS_EXPIRATION", "1h"),
 		JWTRefreshExpiration: getEnv("JWT_REFRESH_EXPIRATION", "7d"),
-	} , nil
+		MaxSessionsPerUser: getEnv("MAX_SESSIONS_PER_USER", "3"),
+		SessionLimitPolicy: getEnv("SESSION_LIMIT_POLICY", string(model.SessionLimitEvictOldest)),
+		// Si no se definen, se reutiliza el secreto JWT
+		RefreshTokenSecret: getEnv("REFRESH_TOKEN_SECRET", jwtSecret),
+		CursorSecret: getEnv("CURSOR_SECRET", jwtSecret),
+		// Si no se define, se usa el flujo por defecto del módulo tasks
+		TaskStatusWorkflow: getEnv("TASK_STATUS_WORKFLOW", ""),
+		TaskTrashRetention: getEnv("TASK_TRASH_RETENTION", "30d"),
+		IdempotencyTTL: getEnv("IDEMPOTENCY_TTL", "24h"),
+		ReminderInterval: getEnv("REMINDER_INTERVAL", "30s"),
+		// Por defecto apunta a un servidor SMTP local de pruebas (MailHog/Mailpit)
+		SMTPHost: getEnv("SMTP_HOST", "localhost"),
+		SMTPPort: getEnv("SMTP_PORT", "1025"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom: getEnv("SMTP_FROM", "no-reply@localhost"),
+		// Sin URL el canal webhook queda deshabilitado
+		WebhookURL: getEnv("WEBHOOK_URL", ""),
+		WebhookSecret: getEnv("WEBHOOK_SECRET", ""),
+	}
+
+	// Una configuración de sesiones o duraciones inválida impide arrancar
+	if _, err := cfg.SessionPolicy(); err != nil {
+		return nil, err
+	}
+	if _, err := cfg.Durations(); err != nil {
+		return nil, err
+	}
+	if err := cfg.checkProduction(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -2,7 +2,9 @@ package config
 
 import (
 	authGormModels "go-task-easy-list/internal/auth/infrastructure/persistence/gorm"
+	sharedGormModels "go-task-easy-list/internal/shared/infrastructure/persistence/gorm"
 	tasksGormModels "go-task-easy-list/internal/tasks/infrastructure/persistence/gorm"
+	"log"
 	"time"
 
 	"github.com/glebarez/sqlite"
@@ -22,14 +24,29 @@ func InitDatabase(dbPath string) (*gorm.DB, error) {  // o cambiar a databaseUrl
 
 	db.Exec("PRAGMA foreign_keys = ON")
 
+	if err := dropPlaintextSessions(db); err != nil {
+		return nil, err
+	}
+
 	// AutoMigrate: Crear tablas automáticamente
 	if err := db.AutoMigrate(
 		&authGormModels.UserModel{},
 		&authGormModels.SessionModel{},
+		&authGormModels.RotatedRefreshTokenModel{},
 
 		&tasksGormModels.TaskStatusModel{},
 		&tasksGormModels.TaskPriorityModel{},
+		&tasksGormModels.ProjectModel{},
+		&tasksGormModels.ProjectMemberModel{},
 		&tasksGormModels.TaskModel{},
+		&tasksGormModels.TaskSeriesModel{},
+		&tasksGormModels.ReminderModel{},
+		&tasksGormModels.TaskEventModel{},
+		&tasksGormModels.LabelModel{},
+		&tasksGormModels.TaskLabelModel{},
+		&tasksGormModels.TaskDependencyModel{},
+
+		&sharedGormModels.IdempotencyKeyModel{},
 	); err != nil {
 		return nil, err
 	}
@@ -38,11 +55,54 @@ func InitDatabase(dbPath string) (*gorm.DB, error) {  // o cambiar a databaseUrl
 		return nil, err
 	}
 
+	if err := nullZeroTaskDates(db); err != nil {
+		return nil, err
+	}
+
+	// Índice de búsqueda de texto completo (FTS5, solo SQLite)
+	if err := tasksGormModels.SetupTaskSearchIndex(db); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
+// dropPlaintextSessions - Las bases anteriores guardaban los refresh tokens en claro
+// (columna refresh_token). Esas sesiones no se pueden migrar al hash sin dejar los tokens
+// a la vista, así que se eliminan antes de migrar y los usuarios vuelven a iniciar sesión.
+func dropPlaintextSessions(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&authGormModels.SessionModel{}) || !migrator.HasColumn(&authGormModels.SessionModel{}, "refresh_token") {
+		return nil
+	}
+
+	log.Println("Sesiones con refresh tokens en claro: se eliminan (hay que volver a iniciar sesión)")
+	return migrator.DropTable(&authGormModels.RotatedRefreshTokenModel{}, &authGormModels.SessionModel{})
+}
+
+// nullZeroTaskDates - Las bases anteriores guardaban "sin fecha" como la fecha cero;
+// se convierten a NULL para que los filtros y el orden por fecha las traten como vacías
+func nullZeroTaskDates(db *gorm.DB) error {
+	for _, column := range []string{"starts_at", "due_date", "completed_at"} {
+		// Unscoped: también las tareas en la papelera. UpdateColumn no toca updated_at
+		if err := db.Unscoped().Model(&tasksGormModels.TaskModel{}).
+			Where(column+" = ?", time.Time{}).
+			UpdateColumn(column, nil).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Si es que se desea tablas predefinidas para datos estáticos de las foreign keys
 func seedTaskCatalogs(db *gorm.DB) error {
+	// Bases creadas antes de las categorías: el estado COMPLETED es el único terminado
+	if err := db.Model(&tasksGormModels.TaskStatusModel{}).
+		Where("id = ? AND category <> ?", 3, "done").
+		Update("category", "done").Error; err != nil {
+		return err
+	}
+
 	// Verificar si ya existen datos
 	var count int64
 	db.Model(&tasksGormModels.TaskStatusModel{}).Count(&count)
@@ -51,12 +111,13 @@ func seedTaskCatalogs(db *gorm.DB) error {
 	}
 
 	now := time.Now()
+	code := func(c string) *string { return &c }
 
 	// Insertar estados
 	statuses := []tasksGormModels.TaskStatusModel{
-		{ID: 1, Code: "PENDING", Name: "Pendiente", Description: "Tarea pendiente por iniciar", CreatedAt: now},
-		{ID: 2, Code: "IN_PROGRESS", Name: "En Progreso", Description: "Tarea en proceso", CreatedAt: now},
-		{ID: 3, Code: "COMPLETED", Name: "Completada", Description: "Tarea finalizada", CreatedAt: now},
+		{ID: 1, Code: code("PENDING"), Name: "Pendiente", Description: "Tarea pendiente por iniciar", Category: "open", CreatedAt: now},
+		{ID: 2, Code: code("IN_PROGRESS"), Name: "En Progreso", Description: "Tarea en proceso", Category: "open", CreatedAt: now},
+		{ID: 3, Code: code("COMPLETED"), Name: "Completada", Description: "Tarea finalizada", Category: "done", CreatedAt: now},
 	}
 
 	for _, status := range statuses {
@@ -67,9 +128,9 @@ func seedTaskCatalogs(db *gorm.DB) error {
 
 	// Insertar prioridades
 	priorities := []tasksGormModels.TaskPriorityModel{
-		{ID: 1, Code: "LOW", Name: "Baja", Level: 1, CreatedAt: now},
-		{ID: 2, Code: "MEDIUM", Name: "Media", Level: 2, CreatedAt: now},
-		{ID: 3, Code: "HIGH", Name: "Alta", Level: 3, CreatedAt: now},
+		{ID: 1, Code: code("LOW"), Name: "Baja", Level: 1, CreatedAt: now},
+		{ID: 2, Code: code("MEDIUM"), Name: "Media", Level: 2, CreatedAt: now},
+		{ID: 3, Code: code("HIGH"), Name: "Alta", Level: 3, CreatedAt: now},
 	}
 
 	for _, priority := range priorities {
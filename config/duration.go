@@ -0,0 +1,20 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration acepta el formato de time.ParseDuration ("90m", "12h") y además
+// días enteros con el sufijo "d" ("7d", "30d")
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Durations - Intervalos y plazos configurables de los módulos, ya interpretados
+type Durations struct {
+	ReminderInterval time.Duration // cada cuánto se revisan los recordatorios vencidos
+	TrashRetention   time.Duration // tiempo en la papelera antes de borrar una tarea
+	IdempotencyTTL   time.Duration // tiempo que se guarda cada Idempotency-Key
+}
+
+// Durations interpreta REMINDER_INTERVAL, TASK_TRASH_RETENTION e IDEMPOTENCY_TTL.
+// LoadConfig las valida al arrancar, así que después no debería fallar.
+func (c *Config) Durations() (Durations, error) {
+	reminderInterval, err := positiveDuration("REMINDER_INTERVAL", c.ReminderInterval)
+	if err != nil {
+		return Durations{}, err
+	}
+	trashRetention, err := positiveDuration("TASK_TRASH_RETENTION", c.TaskTrashRetention)
+	if err != nil {
+		return Durations{}, err
+	}
+	idempotencyTTL, err := positiveDuration("IDEMPOTENCY_TTL", c.IdempotencyTTL)
+	if err != nil {
+		return Durations{}, err
+	}
+
+	return Durations{
+		ReminderInterval: reminderInterval,
+		TrashRetention:   trashRetention,
+		IdempotencyTTL:   idempotencyTTL,
+	}, nil
+}
+
+// positiveDuration interpreta la variable name con ParseDuration y exige que sea mayor que cero
+func positiveDuration(name, value string) (time.Duration, error) {
+	duration, err := ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%s inválido (%q), usar una duración positiva (\"30s\", \"12h\", \"7d\")", name, value)
+	}
+	return duration, nil
+}
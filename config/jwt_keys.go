@@ -0,0 +1,41 @@
+package config
+
+import (
+	"errors"
+	"go-task-easy-list/internal/shared/jwtkeys"
+	"log"
+)
+
+// devKeyID - kid de la clave temporal que se genera en desarrollo
+const devKeyID = "dev"
+
+// JWTKeySet carga las claves de firma de JWT_KEYS_DIR. Sin directorio (solo fuera de
+// producción) genera una clave Ed25519 temporal: los tokens dejan de valer al reiniciar.
+func (c *Config) JWTKeySet() (*jwtkeys.KeySet, error) {
+	if c.JWTKeysDir != "" {
+		return jwtkeys.LoadDir(c.JWTKeysDir, c.JWTActiveKID)
+	}
+
+	log.Println("JWT_KEYS_DIR no definido: se firma con una clave temporal (los tokens no sobreviven a un reinicio)")
+	key, err := jwtkeys.GenerateEd25519(devKeyID)
+	if err != nil {
+		return nil, err
+	}
+	return jwtkeys.NewKeySet(devKeyID, key)
+}
+
+// checkProduction impide arrancar en producción con el secreto de ejemplo o sin claves de firma
+func (c *Config) checkProduction() error {
+	if c.AppEnv != EnvProduction {
+		return nil
+	}
+	for _, secret := range []string{c.JWTSecret, c.RefreshTokenSecret, c.CursorSecret} {
+		if secret == defaultJWTSecret {
+			return errors.New("APP_ENV=production no admite el JWT_SECRET por defecto: definir JWT_SECRET (o REFRESH_TOKEN_SECRET y CURSOR_SECRET) con un valor propio")
+		}
+	}
+	if c.JWTKeysDir == "" {
+		return errors.New("APP_ENV=production necesita JWT_KEYS_DIR con las claves de firma de los JWT")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"go-task-easy-list/internal/auth/domain/model"
+	"strconv"
+)
+
+// SessionPolicy interpreta la configuración de tokens y sesiones del módulo auth.
+// LoadConfig la valida al arrancar, así que después no debería fallar.
+func (c *Config) SessionPolicy() (model.SessionPolicy, error) {
+	accessTTL, err := ParseDuration(c.JWTAccessExpiration)
+	if err != nil || accessTTL <= 0 {
+		return model.SessionPolicy{}, fmt.Errorf("JWT_ACCESS_EXPIRATION inválido (%q)", c.JWTAccessExpiration)
+	}
+	refreshTTL, err := ParseDuration(c.JWTRefreshExpiration)
+	if err != nil || refreshTTL <= 0 {
+		return model.SessionPolicy{}, fmt.Errorf("JWT_REFRESH_EXPIRATION inválido (%q)", c.JWTRefreshExpiration)
+	}
+	if accessTTL >= refreshTTL {
+		return model.SessionPolicy{}, fmt.Errorf("JWT_ACCESS_EXPIRATION (%s) debe ser menor que JWT_REFRESH_EXPIRATION (%s)", c.JWTAccessExpiration, c.JWTRefreshExpiration)
+	}
+
+	maxSessions, err := strconv.Atoi(c.MaxSessionsPerUser)
+	if err != nil || maxSessions < 0 {
+		return model.SessionPolicy{}, fmt.Errorf("MAX_SESSIONS_PER_USER inválido (%q), usar un entero (0 = sin límite)", c.MaxSessionsPerUser)
+	}
+	onLimit, err := model.ParseSessionLimitPolicy(c.SessionLimitPolicy)
+	if err != nil {
+		return model.SessionPolicy{}, fmt.Errorf("SESSION_LIMIT_POLICY: %w", err)
+	}
+
+	return model.SessionPolicy{
+		AccessTokenTTL:  accessTTL,
+		RefreshTokenTTL: refreshTTL,
+		MaxSessions:     maxSessions,
+		OnLimit:         onLimit,
+	}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"go-task-easy-list/internal/auth/domain/model"
 	"go-task-easy-list/internal/auth/domain/repository"
+	"go-task-easy-list/internal/shared/jwtkeys"
+	"log"
 	"regexp"
 	"time"
 
@@ -19,22 +21,43 @@ var (
 	ErrInvalidPassword = errors.New("la contraseña debe tener al menos 8 caracteres")
 	ErrUserNotFound = errors.New("usuario no encontrado")
 	ErrInvalidCredentials = errors.New("credenciales inválidas")
+	ErrInvalidRefreshToken = errors.New("refresh token inválido")
+	ErrExpiredRefreshToken = errors.New("refresh token expirado")
+	ErrRefreshTokenReused = errors.New("refresh token reutilizado; la sesión se cerró por seguridad")
+	ErrSessionNotFound = errors.New("sesión no encontrada")
+	ErrSessionRequired = errors.New("el token no identifica la sesión; vuelve a iniciar sesión")
+	ErrSessionLimit = errors.New("alcanzaste el límite de sesiones activas; cierra alguna desde otro dispositivo")
+)
+
+const (
+	maxDeviceNameLength = 100
+	maxUserAgentLength  = 255
 )
 
 type AuthService struct {
 	userRepo   repository.UserRepository
 	sessionRepo repository.SessionRepository
-	jwtSecret  string
+	signingKeys *jwtkeys.KeySet
+	refreshTokenSecret []byte
+	policy     model.SessionPolicy
 }
 
-func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, jwtSecret string) *AuthService {
+// signingKeys firma los access tokens con la clave activa (cabecera kid)
+func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, signingKeys *jwtkeys.KeySet, refreshTokenSecret string, policy model.SessionPolicy) *AuthService {
 	return &AuthService{
 		userRepo:  userRepo,
 		sessionRepo: sessionRepo,
-		jwtSecret: jwtSecret,
+		signingKeys: signingKeys,
+		refreshTokenSecret: []byte(refreshTokenSecret),
+		policy:    policy,
 	}
 }
 
+// MaxSessions - Límite de sesiones simultáneas por usuario (0 = sin límite)
+func (s *AuthService) MaxSessions() int {
+	return s.policy.MaxSessions
+}
+
 // Register- Registrar un usuario
 func (s *AuthService) Register(email, password, name string) (*model.User, error) {
 	// 1. Validar email (formato, no duplicado)
@@ -77,8 +100,8 @@ func (s *AuthService) Register(email, password, name string) (*model.User, error
 	return user, nil
 }
 
-// Login - Iniciar sesión
-func (s *AuthService) Login(email, password string) (*model.User, string, string, bool, error) {
+// Login - Iniciar sesión; device identifica el dispositivo en el listado de sesiones
+func (s *AuthService) Login(email, password string, device model.DeviceInfo) (*model.User, string, string, bool, error) {
 	user, err := s.userRepo.FindByEmail(email)
 	if err != nil || user == nil {
 		return nil, "", "", false, ErrInvalidCredentials
@@ -94,33 +117,40 @@ func (s *AuthService) Login(email, password string) (*model.User, string, string
 
 	s.cleanExpiredSessions(user.ID)
 
-	activeSessions, _ := s.sessionRepo.CountByUserID(user.ID)
-	sessionRemoved := false
-	if activeSessions >= 3 {
-		s.sessionRepo.DeleteOldestByUserID(user.ID)
-		sessionRemoved = true
+	sessionRemoved, err := s.enforceSessionLimit(user.ID)
+	if err != nil {
+		return nil, "", "", false, err
 	}
 
-	accessToken, err := s.generateAccessToken(user.ID, user.Email)
+	refreshToken, err := generateRefreshToken()
 	if err != nil {
 		return nil, "", "", false, err
 	}
 
-	refreshToken := uuid.New().String()
-
-	// 6. Guardar sesión
+	// 6. Guardar sesión (solo el hash del refresh token)
+	now := time.Now()
 	session := &model.Session{
 		ID:           uuid.New().String(),
 		UserID:       user.ID,
-		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(7 * 24 * time.Hour),
-		CreatedAt:    time.Now(),
+		RefreshTokenHash: s.hashRefreshToken(refreshToken),
+		DeviceName:   truncate(device.DeviceName, maxDeviceNameLength),
+		UserAgent:    truncate(device.UserAgent, maxUserAgentLength),
+		IPAddress:    device.IPAddress,
+		LastUsedAt:   now,
+		ExpiresAt:    now.Add(s.policy.RefreshTokenTTL),
+		CreatedAt:    now,
 	}
 
 	if err = s.sessionRepo.Create(session); err != nil {
 		return nil, "", "", false, err
 	}
 
+	// El access token lleva el ID de la sesión para poder cerrarla por separado
+	accessToken, err := s.generateAccessToken(user.ID, user.Email, session.ID)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+
 	userResponse := &model.User{
 		ID:        user.ID,
 		Email:     user.Email,
@@ -133,32 +163,75 @@ func (s *AuthService) Login(email, password string) (*model.User, string, string
 	return userResponse, accessToken, refreshToken, sessionRemoved, nil
 }
 
-func (s *AuthService) Logout(userID string) error {
-	return s.sessionRepo.DeleteByUserID(userID)
+// Logout - Cierra solo la sesión actual. Los tokens emitidos antes de identificar la
+// sesión (sin sessionID) cierran todas las del usuario.
+func (s *AuthService) Logout(userID, sessionID string) error {
+	if sessionID == "" {
+		return s.sessionRepo.DeleteByUserID(userID)
+	}
+	return s.RevokeSession(userID, sessionID)
+}
+
+// RevokeSession - Cierra una sesión (dispositivo) del usuario
+func (s *AuthService) RevokeSession(userID, sessionID string) error {
+	session, err := s.sessionRepo.FindByID(sessionID)
+	if err != nil || session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.sessionRepo.DeleteByID(session.ID)
+}
+
+// LogoutOthers - Cierra todas las sesiones del usuario salvo la actual
+func (s *AuthService) LogoutOthers(userID, sessionID string) error {
+	if sessionID == "" {
+		return ErrSessionRequired
+	}
+	return s.sessionRepo.DeleteOthersByUserID(userID, sessionID)
 }
 
-func (s *AuthService) RefreshToken(refreshToken string) (newAccessToken string, err error) {
-	session ,err := s.sessionRepo.FindByRefreshToken(refreshToken)
+// RefreshToken - Rota el refresh token: emite uno nuevo y el presentado deja de ser válido.
+// La sesión (familia de tokens) conserva su caducidad. Si se presenta un token ya rotado
+// se asume que fue robado y se cierra la sesión entera.
+func (s *AuthService) RefreshToken(refreshToken string, device model.DeviceInfo) (newAccessToken, newRefreshToken string, err error) {
+	session, err := s.sessionRepo.FindByRefreshTokenHash(s.hashRefreshToken(refreshToken))
 	if err != nil {
-		return "", errors.New("refresh token inválido")
+		return "", "", s.detectReuse(refreshToken)
 	}
 
 	if session.IsExpired() {
-		s.sessionRepo.DeleteByUserID(session.UserID)
-		return "", errors.New("refresh token expirado")
+		// Solo caduca la sesión de este dispositivo; las demás siguen abiertas
+		if err := s.sessionRepo.DeleteByID(session.ID); err != nil {
+			return "", "", err
+		}
+		return "", "", ErrExpiredRefreshToken
 	}
 
 	user, err := s.userRepo.FindByID(session.UserID)
 	if err != nil || user == nil {
-		return "", errors.New("usuario no encontrado")
+		return "", "", ErrUserNotFound
+	}
+
+	newRefreshToken, err = generateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+	session.UserAgent = truncate(device.UserAgent, maxUserAgentLength)
+	session.IPAddress = device.IPAddress
+	session.LastUsedAt = time.Now()
+	if err := s.sessionRepo.RotateRefreshToken(session, s.hashRefreshToken(newRefreshToken)); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyRotated) {
+			// Otra petición lo rotó a la vez: es el mismo token presentado dos veces
+			return "", "", s.detectReuse(refreshToken)
+		}
+		return "", "", err
 	}
 
-	newAccessToken, err = s.generateAccessToken(user.ID, user.Email)
+	newAccessToken, err = s.generateAccessToken(user.ID, user.Email, session.ID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	return newAccessToken, nil
+	return newAccessToken, newRefreshToken, nil
 }
 
 func (s *AuthService) GetActiveSessions(userId string) ([]*model.Session, error) {
@@ -166,16 +239,26 @@ func (s *AuthService) GetActiveSessions(userId string) ([]*model.Session, error)
 }
 
 // --------------------- Helpers ---------------------
-func (s *AuthService) generateAccessToken(userID, email string) (string ,error) {
+func (s *AuthService) generateAccessToken(userID, email, sessionID string) (string ,error) {
 	claims := jwt.MapClaims{
+		"sub": userID,
 		"userId": userID,
 		"email": email,
-		"exp": time.Now().Add(1 * time.Hour).Unix(),
+		"sid": sessionID,
+		"exp": time.Now().Add(s.policy.AccessTokenTTL).Unix(),
 		"iat": time.Now().Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.signingKeys.Sign(claims)
+}
+
+// truncate recorta s a max caracteres
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
 }
 
 func isValidEmail(email string) bool {
@@ -183,6 +266,48 @@ func isValidEmail(email string) bool {
 	return emailRegex.MatchString(email)
 }
 
+// detectReuse revoca la sesión si el token ya fue rotado (reutilización); si no es
+// ningún token conocido solo lo rechaza
+func (s *AuthService) detectReuse(refreshToken string) error {
+	sessionID, err := s.sessionRepo.FindSessionIDByRotatedTokenHash(s.hashRefreshToken(refreshToken))
+	if err != nil || sessionID == "" {
+		return ErrInvalidRefreshToken
+	}
+
+	if err := s.sessionRepo.DeleteByID(sessionID); err != nil {
+		log.Printf("[auth] error revocando la sesión %s por reutilización de refresh token: %v", sessionID, err)
+	}
+	log.Printf("[auth] refresh token reutilizado en la sesión %s: sesión revocada", sessionID)
+	return ErrRefreshTokenReused
+}
+
+// enforceSessionLimit aplica la política cuando el usuario ya tiene el máximo de sesiones:
+// cierra las más antiguas hasta dejar sitio (retorna true) o rechaza el login
+func (s *AuthService) enforceSessionLimit(userID string) (bool, error) {
+	if s.policy.MaxSessions == 0 {
+		return false, nil
+	}
+
+	activeSessions, err := s.sessionRepo.CountByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	if activeSessions < int64(s.policy.MaxSessions) {
+		return false, nil
+	}
+	if s.policy.OnLimit == model.SessionLimitReject {
+		return false, ErrSessionLimit
+	}
+
+	// Si se bajó el límite puede haber más de una sesión sobrante
+	for ; activeSessions >= int64(s.policy.MaxSessions); activeSessions-- {
+		if err := s.sessionRepo.DeleteOldestByUserID(userID); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // Elimina sesiones expiradas del usuario
 func (s *AuthService) cleanExpiredSessions(userID string) {
 	s.sessionRepo.DeleteExpiredByUserID(userID)
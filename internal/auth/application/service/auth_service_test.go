@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"errors"
+	"go-task-easy-list/internal/auth/application/service"
+	"go-task-easy-list/internal/auth/domain/model"
+	gormRepo "go-task-easy-list/internal/auth/infrastructure/persistence/gorm"
+	"go-task-easy-list/internal/shared/jwtkeys"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	testEmail    = "ana@example.com"
+	testPassword = "Passw0rd!"
+)
+
+// newTestAuthService crea el servicio sobre una base SQLite temporal con un usuario registrado
+func newTestAuthService(t *testing.T) *service.AuthService {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "auth.db") + "?_pragma=busy_timeout(5000)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("abrir base: %v", err)
+	}
+	if err := db.AutoMigrate(&gormRepo.UserModel{}, &gormRepo.SessionModel{}, &gormRepo.RotatedRefreshTokenModel{}); err != nil {
+		t.Fatalf("migrar: %v", err)
+	}
+
+	key, err := jwtkeys.GenerateEd25519("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := jwtkeys.NewKeySet("test", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authService := service.NewAuthService(
+		gormRepo.NewUserRepository(db),
+		gormRepo.NewSessionRepository(db),
+		keys,
+		"refresh-secret",
+		model.SessionPolicy{
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: time.Hour,
+			OnLimit:         model.SessionLimitEvictOldest,
+		},
+	)
+	if _, err := authService.Register(testEmail, testPassword, "Ana"); err != nil {
+		t.Fatalf("registrar: %v", err)
+	}
+	return authService
+}
+
+func login(t *testing.T, authService *service.AuthService, deviceName string) string {
+	t.Helper()
+
+	_, _, refreshToken, _, err := authService.Login(testEmail, testPassword, model.DeviceInfo{DeviceName: deviceName})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	return refreshToken
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	authService := newTestAuthService(t)
+	first := login(t, authService, "portátil")
+
+	accessToken, second, err := authService.RefreshToken(first, model.DeviceInfo{})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if accessToken == "" || second == "" || second == first {
+		t.Fatalf("la rotación no emitió un refresh token nuevo")
+	}
+
+	if _, _, err := authService.RefreshToken(second, model.DeviceInfo{}); err != nil {
+		t.Errorf("el refresh token rotado no se acepta: %v", err)
+	}
+	if _, _, err := authService.RefreshToken("desconocido", model.DeviceInfo{}); !errors.Is(err, service.ErrInvalidRefreshToken) {
+		t.Errorf("token desconocido: err = %v, se esperaba ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesSession(t *testing.T) {
+	authService := newTestAuthService(t)
+	stolen := login(t, authService, "portátil")
+	otherDevice := login(t, authService, "móvil")
+
+	_, current, err := authService.RefreshToken(stolen, model.DeviceInfo{})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	// Presentar de nuevo el token ya rotado cierra la sesión entera
+	if _, _, err := authService.RefreshToken(stolen, model.DeviceInfo{}); !errors.Is(err, service.ErrRefreshTokenReused) {
+		t.Fatalf("reutilización: err = %v, se esperaba ErrRefreshTokenReused", err)
+	}
+	if _, _, err := authService.RefreshToken(current, model.DeviceInfo{}); !errors.Is(err, service.ErrInvalidRefreshToken) {
+		t.Errorf("el token vigente de la sesión revocada: err = %v, se esperaba ErrInvalidRefreshToken", err)
+	}
+
+	// Las sesiones de otros dispositivos no se ven afectadas
+	if _, _, err := authService.RefreshToken(otherDevice, model.DeviceInfo{}); err != nil {
+		t.Errorf("la sesión de otro dispositivo se cerró: %v", err)
+	}
+}
+
+func TestRefreshTokenConcurrentReuse(t *testing.T) {
+	authService := newTestAuthService(t)
+	refreshToken := login(t, authService, "portátil")
+
+	const attempts = 8
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		winners  []string
+		reused   int
+		rejected int
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, newToken, err := authService.RefreshToken(refreshToken, model.DeviceInfo{})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				winners = append(winners, newToken)
+			case errors.Is(err, service.ErrRefreshTokenReused):
+				reused++
+			case errors.Is(err, service.ErrInvalidRefreshToken):
+				// La sesión ya la revocó otra petición concurrente
+				rejected++
+			default:
+				t.Errorf("error inesperado: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Solo una petición puede rotar el token; las demás lo presentan ya rotado
+	if len(winners) != 1 {
+		t.Fatalf("rotaciones correctas = %d, se esperaba 1", len(winners))
+	}
+	if reused == 0 || reused+rejected != attempts-1 {
+		t.Fatalf("reutilizaciones = %d, rechazos = %d; se esperaban %d en total con al menos una reutilización", reused, rejected, attempts-1)
+	}
+	if _, _, err := authService.RefreshToken(winners[0], model.DeviceInfo{}); !errors.Is(err, service.ErrInvalidRefreshToken) {
+		t.Errorf("el token de la rotación ganadora sigue activo tras la reutilización: err = %v", err)
+	}
+}
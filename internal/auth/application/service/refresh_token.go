@@ -0,0 +1,29 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// refreshTokenBytes - Entropía del refresh token (256 bits)
+const refreshTokenBytes = 32
+
+// generateRefreshToken - Token aleatorio en base64url; solo lo conoce el cliente
+func generateRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashRefreshToken - HMAC-SHA256 del token con el secreto del servidor. Es lo único que
+// se guarda: con la base de datos sola no se pueden reconstruir tokens válidos.
+func (s *AuthService) hashRefreshToken(token string) string {
+	mac := hmac.New(sha256.New, s.refreshTokenSecret)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -3,11 +3,23 @@ package model
 import "time"
 
 type Session struct {
-	ID           string    `json:"id"`
-	UserID       string    `json:"userId"`
-	RefreshToken string    `json:"refreshToken"`
-	ExpiresAt    time.Time `json:"expiresAt"`
-	CreatedAt    time.Time `json:"createdAt"`
+	ID     string `json:"id"`
+	UserID string `json:"userId"`
+	// RefreshTokenHash - HMAC del refresh token; el token en claro no se guarda
+	RefreshTokenHash string    `json:"-"`
+	DeviceName       string    `json:"deviceName,omitempty"`
+	UserAgent        string    `json:"userAgent,omitempty"`
+	IPAddress        string    `json:"ipAddress,omitempty"`
+	LastUsedAt       time.Time `json:"lastUsedAt"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// DeviceInfo - Datos del dispositivo desde el que se inicia o renueva una sesión
+type DeviceInfo struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
 }
 
 func (s *Session) IsExpired() bool {
@@ -16,4 +28,4 @@ func (s *Session) IsExpired() bool {
 
 func (s *Session) IsValid() bool {
 	return !s.IsExpired()
-}
\ No newline at end of file
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// SessionLimitPolicy - Qué hacer en el login cuando el usuario ya tiene el máximo de sesiones
+type SessionLimitPolicy string
+
+const (
+	SessionLimitEvictOldest SessionLimitPolicy = "evict_oldest" // cerrar la sesión más antigua
+	SessionLimitReject      SessionLimitPolicy = "reject"       // rechazar el login
+)
+
+// ParseSessionLimitPolicy valida el nombre de la política
+func ParseSessionLimitPolicy(value string) (SessionLimitPolicy, error) {
+	switch policy := SessionLimitPolicy(value); policy {
+	case SessionLimitEvictOldest, SessionLimitReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("política de límite de sesiones desconocida %q (usar %s o %s)", value, SessionLimitEvictOldest, SessionLimitReject)
+	}
+}
+
+// SessionPolicy - Duración de los tokens y límite de sesiones simultáneas por usuario
+type SessionPolicy struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration // duración de la sesión; rotar el refresh token no la alarga
+	MaxSessions     int           // 0 = sin límite
+	OnLimit         SessionLimitPolicy
+}
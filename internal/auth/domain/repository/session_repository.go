@@ -1,10 +1,16 @@
 package repository
 
-import "go-task-easy-list/internal/auth/domain/model"
+import (
+	"errors"
+	"go-task-easy-list/internal/auth/domain/model"
+)
+
+// ErrTokenAlreadyRotated - El refresh token ya no es el vigente de la sesión
+var ErrTokenAlreadyRotated = errors.New("el refresh token ya fue rotado")
 
 type SessionRepository interface {
 	Create(session *model.Session) error
-	FindByRefreshToken(token string) (*model.Session, error)
+	FindByRefreshTokenHash(tokenHash string) (*model.Session, error)
 	FindByID(id string) (*model.Session, error)
 	FindActiveByUserID(userID string) ([]*model.Session, error)
 	DeleteByUserID(userID string) error
@@ -13,4 +19,14 @@ type SessionRepository interface {
 	DeleteOldestByUserID(userID string) error
 	DeleteExpiredByUserID(userID string) error
 	HasActiveSession(userID string) (bool, error)
+	// RotateRefreshToken sustituye el refresh token vigente de la sesión por newTokenHash
+	// (junto con el uso más reciente: LastUsedAt, UserAgent e IPAddress) y guarda el anterior
+	// como rotado. Retorna ErrTokenAlreadyRotated si otra petición ya lo rotó.
+	RotateRefreshToken(session *model.Session, newTokenHash string) error
+	// FindSessionIDByRotatedTokenHash - Sesión a la que perteneció un refresh token ya rotado ("" si no existe)
+	FindSessionIDByRotatedTokenHash(tokenHash string) (string, error)
+	// DeleteByID elimina la sesión con todos sus refresh tokens rotados
+	DeleteByID(id string) error
+	// DeleteOthersByUserID elimina todas las sesiones del usuario salvo keepSessionID
+	DeleteOthersByUserID(userID, keepSessionID string) error
 }
\ No newline at end of file
@@ -2,47 +2,62 @@ package config
 
 import (
 	"go-task-easy-list/internal/auth/application/service"
+	"go-task-easy-list/internal/auth/domain/model"
 	"go-task-easy-list/internal/auth/infrastructure/http/handler"
 	gormRepo "go-task-easy-list/internal/auth/infrastructure/persistence/gorm"
 	"go-task-easy-list/internal/shared/infrastructure/middleware"
+	"go-task-easy-list/internal/shared/jwtkeys"
 
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
 )
 
 type AuthModule struct {
-	Handler *handler.AuthHandler
+	Handler     *handler.AuthHandler
+	JWKSHandler *handler.JWKSHandler
 }
 
-func NewAuthModule(db *gorm.DB, jwtSecre string) *AuthModule {
+// signingKeys firma los access tokens, refreshTokenSecret es la clave del HMAC con el que
+// se guardan los refresh tokens y policy fija la duración de los tokens y el límite de sesiones
+func NewAuthModule(db *gorm.DB, signingKeys *jwtkeys.KeySet, refreshTokenSecret string, policy model.SessionPolicy) *AuthModule {
 	// Repositories
 	userRepo := gormRepo.NewUserRepository(db)
 	sessionRepo := gormRepo.NewSessionRepository(db)
 
 	// Services
-	authService := service.NewAuthService(userRepo, sessionRepo, jwtSecre)
+	authService := service.NewAuthService(userRepo, sessionRepo, signingKeys, refreshTokenSecret, policy)
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(authService)
+	jwksHandler := handler.NewJWKSHandler(signingKeys)
 
 	return &AuthModule{
-		Handler: authHandler,
+		Handler:     authHandler,
+		JWKSHandler: jwksHandler,
 	}
 }
 
 // RegisterRoutes registra las rutas del módulo auth
-func (m *AuthModule) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+// idempotency repite la respuesta de los POST reintentados con la misma Idempotency-Key.
+// No se aplica a login ni refresh: su respuesta contiene tokens que no deben guardarse.
+func (m *AuthModule) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware, idempotency *middleware.Idempotency) {
+	// Claves públicas para que otros servicios verifiquen los access tokens
+	r.Get("/.well-known/jwks.json", m.JWKSHandler.GetJWKS)
+
 	r.Route("/api/auth", func(r chi.Router) {
 		// Rutas públicas sin autenticación
-		r.Post("/register", m.Handler.Register)
+		r.With(idempotency.Handle).Post("/register", m.Handler.Register)
 		r.Post("/login", m.Handler.Login)
 		r.Post("/refresh", m.Handler.RefreshToken)
 
 		// Rutas protegidas (requiren JWT)
 		r.Group(func(r chi.Router) {
 			r.Use(authMiddleware.RequireAuth)
+			r.Use(idempotency.Handle)
 			r.Post("/logout", m.Handler.Logout)
+			r.Post("/logout-others", m.Handler.LogoutOthers)
 			r.Get("/sessions", m.Handler.GetSessions)
+			r.Delete("/sessions/{id}", m.Handler.RevokeSession)
 		})
 	})
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	format "go-task-easy-list/internal/shared/http/utils"
 	"go-task-easy-list/internal/auth/application/service"
 	sharedhttp "go-task-easy-list/internal/shared/http"
@@ -9,6 +10,7 @@ import (
 	sharedValidation "go-task-easy-list/internal/shared/validation"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -33,6 +35,8 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// DeviceName - Nombre opcional con el que se muestra la sesión (p. ej. "iPhone de Ana")
+	DeviceName string `json:"deviceName"`
 }
 
 type AuthResponse struct {
@@ -76,7 +80,11 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, accessToken, refreshToken, sessionRemoved, err := h.authService.Login(req.Email, req.Password)
+	user, accessToken, refreshToken, sessionRemoved, err := h.authService.Login(req.Email, req.Password, deviceInfo(r, req.DeviceName))
+	if err == service.ErrSessionLimit {
+		sharedhttp.ErrorResponse(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		sharedhttp.ErrorResponse(w, http.StatusUnauthorized, "Credenciales inválidas")
 		return
@@ -84,7 +92,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	message := ""
 	if sessionRemoved {
-		message = "Se cerró tu sesión más antigua porque alcanzaste el límite de 3 sesiones activas."
+		message = fmt.Sprintf("Se cerró tu sesión más antigua porque alcanzaste el límite de %d sesiones activas.", h.authService.MaxSessions())
 	}
 
 	response := AuthResponse{
@@ -97,11 +105,12 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	sharedhttp.SuccessResponse(w, http.StatusOK, response)
 }
 
+// Logout - POST /api/auth/logout (cierra solo la sesión actual)
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	// Extraer userId del contexto (establecido por el middleware)
+	// Extraer userId y sesión del contexto (establecidos por el middleware)
 	userID := sharedContext.GetUserID(r.Context())
 
-	if err := h.authService.Logout(userID); err != nil {
+	if err := h.authService.Logout(userID, sharedContext.GetSessionID(r.Context())); err != nil {
 		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al cerrar sesión")
 		return
 	}
@@ -115,8 +124,10 @@ type RefreshRequest struct {
 	RefreshRequest string `json:"refreshToken" validate:"required"`
 }
 
+// RefreshResponse - El refresh token enviado deja de ser válido: el cliente debe guardar el nuevo
 type RefreshResponse struct {
-	AccessToken string `json:"accessToken"`
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
 }
 
 // RefreshToken - POST /api/auth/refresh
@@ -132,14 +143,15 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accessToken, err := h.authService.RefreshToken(req.RefreshRequest)
+	accessToken, refreshToken, err := h.authService.RefreshToken(req.RefreshRequest, deviceInfo(r, ""))
 	if err != nil {
 		sharedhttp.ErrorResponse(w, http.StatusUnauthorized, err.Error())
 		return
 	}
 
 	response := RefreshResponse{
-		AccessToken: accessToken,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 	}
 
 	sharedhttp.SuccessResponse(w, http.StatusOK, response)
@@ -155,5 +167,43 @@ func (h *AuthHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sharedhttp.SuccessResponse(w, http.StatusOK, sessions)
+	currentID := sharedContext.GetSessionID(r.Context())
+	response := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, toSessionResponse(session, currentID))
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, response)
+}
+
+// RevokeSession - DELETE /api/auth/sessions/{id}
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	if err := h.authService.RevokeSession(userID, chi.URLParam(r, "id")); err != nil {
+		status := http.StatusInternalServerError
+		if err == service.ErrSessionNotFound {
+			status = http.StatusNotFound
+		}
+		sharedhttp.ErrorResponse(w, status, err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// LogoutOthers - POST /api/auth/logout-others (cierra las demás sesiones)
+func (h *AuthHandler) LogoutOthers(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	if err := h.authService.LogoutOthers(userID, sharedContext.GetSessionID(r.Context())); err != nil {
+		status := http.StatusInternalServerError
+		if err == service.ErrSessionRequired {
+			status = http.StatusUnauthorized
+		}
+		sharedhttp.ErrorResponse(w, status, err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, map[string]string{"message": "Se cerraron las demás sesiones"})
 }
\ No newline at end of file
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-task-easy-list/internal/shared/jwtkeys"
+	"net/http"
+)
+
+// jwksMaxAge - Segundos que los clientes pueden cachear las claves públicas. Al rotar,
+// conviene publicar la clave nueva al menos este tiempo antes de activarla.
+const jwksMaxAge = "300"
+
+type JWKSHandler struct {
+	signingKeys *jwtkeys.KeySet
+}
+
+func NewJWKSHandler(signingKeys *jwtkeys.KeySet) *JWKSHandler {
+	return &JWKSHandler{signingKeys: signingKeys}
+}
+
+// GetJWKS - GET /.well-known/jwks.json (RFC 7517; sin el envoltorio de respuesta de la API)
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age="+jwksMaxAge)
+	json.NewEncoder(w).Encode(h.signingKeys.JWKS())
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"go-task-easy-list/internal/auth/domain/model"
+	"net"
+	"net/http"
+	"time"
+)
+
+// SessionResponse - Sesión tal como se muestra al usuario; nunca incluye tokens
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"deviceName,omitempty"`
+	UserAgent  string    `json:"userAgent,omitempty"`
+	IPAddress  string    `json:"ipAddress,omitempty"`
+	Current    bool      `json:"current"`
+	LastUsedAt time.Time `json:"lastUsedAt,omitzero"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func toSessionResponse(session *model.Session, currentID string) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		Current:    session.ID == currentID,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+		CreatedAt:  session.CreatedAt,
+	}
+}
+
+// deviceInfo - Datos del dispositivo a partir de la petición. La IP es la de la conexión
+// (detrás de un proxy habilitar middleware.RealIP en main.go).
+func deviceInfo(r *http.Request, deviceName string) model.DeviceInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return model.DeviceInfo{
+		DeviceName: deviceName,
+		UserAgent:  r.UserAgent(),
+		IPAddress:  ip,
+	}
+}
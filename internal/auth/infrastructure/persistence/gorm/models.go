@@ -18,13 +18,32 @@ func (UserModel) TableName() string {
 
 // SessionModel - Representa la tabla sessions
 type SessionModel struct {
-	ID           string    `gorm:"primaryKey;type:text"`
-	UserID       string    `gorm:"not null;index"`
-	RefreshToken string    `gorm:"not null;index"`
-	ExpiresAt    time.Time `gorm:"not null"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	ID               string `gorm:"primaryKey;type:text"`
+	UserID           string `gorm:"not null;index"`
+	RefreshTokenHash string `gorm:"not null;uniqueIndex"` // HMAC-SHA256 del refresh token
+	DeviceName       string
+	UserAgent        string
+	IPAddress        string
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time `gorm:"not null"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
 }
 
 func (SessionModel) TableName() string {
 	return "sessions"
-}
\ No newline at end of file
+}
+
+// RotatedRefreshTokenModel - Refresh tokens ya sustituidos. Se conservan hasta que caduca
+// su sesión para detectar si alguien vuelve a presentarlos.
+type RotatedRefreshTokenModel struct {
+	TokenHash string    `gorm:"primaryKey;type:text"`
+	SessionID string    `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	RotatedAt time.Time `gorm:"not null"`
+
+	Session SessionModel `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE"`
+}
+
+func (RotatedRefreshTokenModel) TableName() string {
+	return "rotated_refresh_tokens"
+}
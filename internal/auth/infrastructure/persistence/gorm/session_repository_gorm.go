@@ -1,6 +1,7 @@
 package gorm
 
 import (
+	"errors"
 	"go-task-easy-list/internal/auth/domain/model"
 	"go-task-easy-list/internal/auth/domain/repository"
 	"time"
@@ -18,39 +19,43 @@ func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
 
 func (r *SessionRepositoryGorm) Create(session *model.Session) error {
 	sessionModel := &SessionModel{
-		ID:           session.ID,
-		UserID:       session.UserID,
-		RefreshToken: session.RefreshToken,
-		ExpiresAt:    session.ExpiresAt,
-		CreatedAt:    session.CreatedAt,
+		ID:               session.ID,
+		UserID:           session.UserID,
+		RefreshTokenHash: session.RefreshTokenHash,
+		DeviceName:       session.DeviceName,
+		UserAgent:        session.UserAgent,
+		IPAddress:        session.IPAddress,
+		LastUsedAt:       session.LastUsedAt,
+		ExpiresAt:        session.ExpiresAt,
+		CreatedAt:        session.CreatedAt,
 	}
 
 	return r.db.Create(sessionModel).Error
 }
 
-func (r *SessionRepositoryGorm) FindByRefreshToken(token string) (*model.Session, error) {
+func (r *SessionRepositoryGorm) FindByRefreshTokenHash(tokenHash string) (*model.Session, error) {
 	sessionModel := &SessionModel{}
-	if err := r.db.Where("refresh_token = ?", token).First(sessionModel).Error; err != nil {
+	if err := r.db.Where("refresh_token_hash = ?", tokenHash).First(sessionModel).Error; err != nil {
 		return nil, err
 	}
 
-	session := &model.Session{
-		ID:           sessionModel.ID,
-		UserID:       sessionModel.UserID,
-		RefreshToken: sessionModel.RefreshToken,
-		ExpiresAt:    sessionModel.ExpiresAt,
-		CreatedAt:    sessionModel.CreatedAt,
-	}
-
-	return session, nil
+	return toDomainSession(sessionModel), nil
 }
 
 func (r *SessionRepositoryGorm) DeleteByUserID(userID string) error {
-	return r.db.Where("user_id = ?", userID).Delete(&SessionModel{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteSessions(tx, "user_id = ?", userID)
+	})
 }
 
 func (r *SessionRepositoryGorm) DeleteExpired() error {
-	return r.db.Where("expires_at < ?", gorm.Expr("NOW()")).Delete(&SessionModel{}).Error
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", now).Delete(&RotatedRefreshTokenModel{}).Error; err != nil {
+			return err
+		}
+		return deleteSessions(tx, "expires_at < ?", now)
+	})
 }
 
 func (r *SessionRepositoryGorm) CountByUserID(userID string) (int64, error) {
@@ -64,11 +69,13 @@ func (r *SessionRepositoryGorm) DeleteOldestByUserID(userID string) error {
 	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").First(&oldestSession).Error; err != nil {
 		return err
 	}
-	return r.db.Delete(&oldestSession).Error
+	return r.DeleteByID(oldestSession.ID)
 }
 
 func (r *SessionRepositoryGorm) DeleteExpiredByUserID(userID string) error {
-	return r.db.Where("user_id = ? AND expires_at < ?", userID, time.Now()).Delete(&SessionModel{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteSessions(tx, "user_id = ? AND expires_at < ?", userID, time.Now())
+	})
 }
 
 func (r *SessionRepositoryGorm) FindActiveByUserID(userID string) ([]*model.Session, error) {
@@ -78,14 +85,8 @@ func (r *SessionRepositoryGorm) FindActiveByUserID(userID string) ([]*model.Sess
 	}
 
 	sessions := make([]*model.Session, len(sessionModels))
-	for i, sm := range sessionModels {
-		sessions[i] = &model.Session{
-			ID:           sm.ID,
-			UserID:       sm.UserID,
-			RefreshToken: sm.RefreshToken,
-			ExpiresAt:    sm.ExpiresAt,
-			CreatedAt:    sm.CreatedAt,
-		}
+	for i := range sessionModels {
+		sessions[i] = toDomainSession(&sessionModels[i])
 	}
 	return sessions, nil
 }
@@ -96,13 +97,7 @@ func (r *SessionRepositoryGorm) FindByID(id string) (*model.Session, error) {
 		return nil, err
 	}
 
-	return &model.Session{
-		ID: sessionModel.ID,
-		UserID: sessionModel.UserID,
-		RefreshToken: sessionModel.RefreshToken,
-		ExpiresAt: sessionModel.ExpiresAt,
-		CreatedAt: sessionModel.CreatedAt,
-	}, nil
+	return toDomainSession(sessionModel), nil
 }
 
 func (r *SessionRepositoryGorm) HasActiveSession(userID string) (bool, error) {
@@ -111,4 +106,89 @@ func (r *SessionRepositoryGorm) HasActiveSession(userID string) (bool, error) {
 		Where("user_id = ? AND expires_at > ?", userID, time.Now()).
 		Count(&count).Error
 	return count > 0, err
-}
\ No newline at end of file
+}
+
+func (r *SessionRepositoryGorm) RotateRefreshToken(session *model.Session, newTokenHash string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Solo rota si el token sigue siendo el vigente: dos peticiones con el mismo token no pueden rotarlo ambas
+		result := tx.Model(&SessionModel{}).
+			Where("id = ? AND refresh_token_hash = ?", session.ID, session.RefreshTokenHash).
+			Updates(map[string]interface{}{
+				"refresh_token_hash": newTokenHash,
+				"user_agent":         session.UserAgent,
+				"ip_address":         session.IPAddress,
+				"last_used_at":       session.LastUsedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrTokenAlreadyRotated
+		}
+
+		if err := tx.Create(&RotatedRefreshTokenModel{
+			TokenHash: session.RefreshTokenHash,
+			SessionID: session.ID,
+			ExpiresAt: session.ExpiresAt,
+			RotatedAt: time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		session.RefreshTokenHash = newTokenHash
+		return nil
+	})
+}
+
+func (r *SessionRepositoryGorm) FindSessionIDByRotatedTokenHash(tokenHash string) (string, error) {
+	var rotated RotatedRefreshTokenModel
+	err := r.db.Where("token_hash = ?", tokenHash).First(&rotated).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return rotated.SessionID, nil
+}
+
+func (r *SessionRepositoryGorm) DeleteByID(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", id).Delete(&RotatedRefreshTokenModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&SessionModel{}).Error
+	})
+}
+
+func (r *SessionRepositoryGorm) DeleteOthersByUserID(userID, keepSessionID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteSessions(tx, "user_id = ? AND id <> ?", userID, keepSessionID)
+	})
+}
+
+// ------------------- Helper ---------------------
+
+// deleteSessions elimina dentro de tx las sesiones que cumplen la condición junto con
+// sus refresh tokens rotados, sin depender de que SQLite aplique el ON DELETE CASCADE
+func deleteSessions(tx *gorm.DB, query string, args ...interface{}) error {
+	sessionIDs := tx.Model(&SessionModel{}).Select("id").Where(query, args...)
+	if err := tx.Where("session_id IN (?)", sessionIDs).Delete(&RotatedRefreshTokenModel{}).Error; err != nil {
+		return err
+	}
+	return tx.Where(query, args...).Delete(&SessionModel{}).Error
+}
+
+func toDomainSession(sm *SessionModel) *model.Session {
+	return &model.Session{
+		ID:               sm.ID,
+		UserID:           sm.UserID,
+		RefreshTokenHash: sm.RefreshTokenHash,
+		DeviceName:       sm.DeviceName,
+		UserAgent:        sm.UserAgent,
+		IPAddress:        sm.IPAddress,
+		LastUsedAt:       sm.LastUsedAt,
+		ExpiresAt:        sm.ExpiresAt,
+		CreatedAt:        sm.CreatedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"go-task-easy-list/internal/auth/domain/model"
+	"go-task-easy-list/internal/auth/domain/repository"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB abre una base SQLite en un archivo temporal. Las claves foráneas quedan
+// desactivadas (el valor por defecto de SQLite) para comprobar que el repositorio no
+// depende del ON DELETE CASCADE.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "sessions.db") + "?_pragma=busy_timeout(5000)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("abrir base: %v", err)
+	}
+	if err := db.AutoMigrate(&SessionModel{}, &RotatedRefreshTokenModel{}); err != nil {
+		t.Fatalf("migrar: %v", err)
+	}
+	return db
+}
+
+func newTestSession(t *testing.T, repo repository.SessionRepository, id, userID, tokenHash string, expiresAt time.Time) *model.Session {
+	t.Helper()
+
+	session := &model.Session{
+		ID:               id,
+		UserID:           userID,
+		RefreshTokenHash: tokenHash,
+		LastUsedAt:       time.Now(),
+		ExpiresAt:        expiresAt,
+		CreatedAt:        time.Now(),
+	}
+	if err := repo.Create(session); err != nil {
+		t.Fatalf("crear sesión %s: %v", id, err)
+	}
+	return session
+}
+
+func countRotated(t *testing.T, db *gorm.DB, sessionID string) int64 {
+	t.Helper()
+
+	var count int64
+	if err := db.Model(&RotatedRefreshTokenModel{}).Where("session_id = ?", sessionID).Count(&count).Error; err != nil {
+		t.Fatalf("contar tokens rotados: %v", err)
+	}
+	return count
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewSessionRepository(db)
+	session := newTestSession(t, repo, "s1", "u1", "hash-1", time.Now().Add(time.Hour))
+
+	if err := repo.RotateRefreshToken(session, "hash-2"); err != nil {
+		t.Fatalf("rotar: %v", err)
+	}
+	if session.RefreshTokenHash != "hash-2" {
+		t.Errorf("RefreshTokenHash = %q, se esperaba hash-2", session.RefreshTokenHash)
+	}
+
+	current, err := repo.FindByRefreshTokenHash("hash-2")
+	if err != nil || current.ID != "s1" {
+		t.Fatalf("el token nuevo no encuentra la sesión: %v", err)
+	}
+	if _, err := repo.FindByRefreshTokenHash("hash-1"); err == nil {
+		t.Error("el token rotado sigue siendo el vigente")
+	}
+
+	sessionID, err := repo.FindSessionIDByRotatedTokenHash("hash-1")
+	if err != nil || sessionID != "s1" {
+		t.Errorf("FindSessionIDByRotatedTokenHash(hash-1) = %q, %v; se esperaba s1", sessionID, err)
+	}
+	sessionID, err = repo.FindSessionIDByRotatedTokenHash("desconocido")
+	if err != nil || sessionID != "" {
+		t.Errorf("FindSessionIDByRotatedTokenHash(desconocido) = %q, %v; se esperaba vacío", sessionID, err)
+	}
+
+	// Volver a rotar desde el token anterior es una reutilización
+	stale := *session
+	stale.RefreshTokenHash = "hash-1"
+	if err := repo.RotateRefreshToken(&stale, "hash-3"); !errors.Is(err, repository.ErrTokenAlreadyRotated) {
+		t.Errorf("rotar un token ya rotado: err = %v, se esperaba ErrTokenAlreadyRotated", err)
+	}
+}
+
+func TestRotateRefreshTokenConcurrent(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewSessionRepository(db)
+	newTestSession(t, repo, "s1", "u1", "hash-0", time.Now().Add(time.Hour))
+
+	const attempts = 8
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded []string
+		rotated   int
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Todas presentan el mismo token vigente
+			session := &model.Session{ID: "s1", UserID: "u1", RefreshTokenHash: "hash-0", ExpiresAt: time.Now().Add(time.Hour)}
+			newHash := fmt.Sprintf("hash-new-%d", i)
+			err := repo.RotateRefreshToken(session, newHash)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded = append(succeeded, newHash)
+			case errors.Is(err, repository.ErrTokenAlreadyRotated):
+				rotated++
+			default:
+				t.Errorf("rotación %d: error inesperado %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(succeeded) != 1 || rotated != attempts-1 {
+		t.Fatalf("rotaciones correctas = %d, ya rotadas = %d; se esperaba 1 y %d", len(succeeded), rotated, attempts-1)
+	}
+	current, err := repo.FindByRefreshTokenHash(succeeded[0])
+	if err != nil || current.ID != "s1" {
+		t.Errorf("el token de la rotación ganadora no es el vigente: %v", err)
+	}
+	if n := countRotated(t, db, "s1"); n != 1 {
+		t.Errorf("tokens rotados = %d, se esperaba 1", n)
+	}
+}
+
+func TestDeleteSessionsRemovesRotatedTokens(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewSessionRepository(db)
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Hour)
+
+	rotate := func(session *model.Session) {
+		t.Helper()
+		if err := repo.RotateRefreshToken(session, session.RefreshTokenHash+"-next"); err != nil {
+			t.Fatalf("rotar %s: %v", session.ID, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		delete  func() error
+		deleted []string
+		kept    []string
+	}{
+		{
+			name:    "DeleteByUserID",
+			delete:  func() error { return repo.DeleteByUserID("u1") },
+			deleted: []string{"a1", "a2"},
+			kept:    []string{"b1"},
+		},
+		{
+			name:    "DeleteOldestByUserID",
+			delete:  func() error { return repo.DeleteOldestByUserID("u1") },
+			deleted: []string{"a1"},
+			kept:    []string{"a2", "b1"},
+		},
+		{
+			name:    "DeleteExpiredByUserID",
+			delete:  func() error { return repo.DeleteExpiredByUserID("u1") },
+			deleted: []string{"a2"},
+			kept:    []string{"a1", "b1"},
+		},
+		{
+			name:    "DeleteExpired",
+			delete:  func() error { return repo.DeleteExpired() },
+			deleted: []string{"a2", "b1"},
+			kept:    []string{"a1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := db.Where("1 = 1").Delete(&RotatedRefreshTokenModel{}).Error; err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Where("1 = 1").Delete(&SessionModel{}).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			// a1 es la más antigua; a2 y b1 están caducadas
+			sessions := []*model.Session{
+				newTestSession(t, repo, "a1", "u1", "a1-hash", future),
+				newTestSession(t, repo, "a2", "u1", "a2-hash", past),
+				newTestSession(t, repo, "b1", "u2", "b1-hash", past),
+			}
+			db.Model(&SessionModel{}).Where("id = ?", "a1").Update("created_at", time.Now().Add(-time.Minute))
+			for _, session := range sessions {
+				rotate(session)
+			}
+
+			if err := tt.delete(); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+
+			for _, id := range tt.deleted {
+				if _, err := repo.FindByID(id); err == nil {
+					t.Errorf("la sesión %s no se eliminó", id)
+				}
+				if n := countRotated(t, db, id); n != 0 {
+					t.Errorf("la sesión %s conserva %d tokens rotados", id, n)
+				}
+			}
+			for _, id := range tt.kept {
+				if _, err := repo.FindByID(id); err != nil {
+					t.Errorf("la sesión %s se eliminó: %v", id, err)
+				}
+				if n := countRotated(t, db, id); n != 1 {
+					t.Errorf("la sesión %s tiene %d tokens rotados, se esperaba 1", id, n)
+				}
+			}
+		})
+	}
+}
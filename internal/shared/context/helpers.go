@@ -5,4 +5,16 @@ import "context"
 func GetUserID(ctx context.Context) string{
 	userID, _ := ctx.Value(UserIdKey).(string)
 	return userID
-}
\ No newline at end of file
+}
+
+// GetSessionID retorna la sesión del access token ("" en tokens emitidos antes de incluirla)
+func GetSessionID(ctx context.Context) string {
+	sessionID, _ := ctx.Value(SessionIdKey).(string)
+	return sessionID
+}
+
+// GetRequestID retorna el identificador de la petición asignado por el middleware RequestID
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIdKey).(string)
+	return requestID
+}
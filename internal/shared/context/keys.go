@@ -3,5 +3,7 @@ package context
 type contextKey string
 
 const (
-	UserIdKey contextKey = "userId"
+	UserIdKey    contextKey = "userId"
+	SessionIdKey contextKey = "sessionId"
+	RequestIdKey contextKey = "requestId"
 )
\ No newline at end of file
@@ -8,9 +8,20 @@ import (
 type Response struct {
 	Success bool        `json:"success"`
 	Data   interface{} `json:"data,omitempty"`
+	Meta   interface{} `json:"meta,omitempty"`
 	Error  string      `json:"error,omitempty"`
 }
 
+// PageMeta - Metadatos de paginación incluidos en respuestas de listados
+// En paginación por cursor se usan NextCursor/PrevCursor en lugar de Offset
+type PageMeta struct {
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+}
+
 func SuccessResponse(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -20,6 +31,28 @@ func SuccessResponse(w http.ResponseWriter, status int, data interface{}) {
 	})
 }
 
+// PaginatedResponse - Igual que SuccessResponse pero incluye metadatos de paginación
+func PaginatedResponse(w http.ResponseWriter, status int, data interface{}, meta interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Response{
+		Success: true,
+		Data:   data,
+		Meta:   meta,
+	})
+}
+
+// ErrorResponseWithData - Error que además incluye datos para el cliente (p. ej. el detalle de qué falló)
+func ErrorResponseWithData(w http.ResponseWriter, status int, message string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Response{
+		Success: false,
+		Data:   data,
+		Error:  message,
+	})
+}
+
 func ErrorResponse(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -27,4 +60,4 @@ func ErrorResponse(w http.ResponseWriter, status int, message string) {
 		Success: false,
 		Error:  message,
 	})
-}
\ No newline at end of file
+}
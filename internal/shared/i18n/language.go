@@ -0,0 +1,61 @@
+// Package i18n elige el idioma de las respuestas a partir de Accept-Language.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Idiomas soportados
+const (
+	Spanish = "es"
+	English = "en"
+
+	Default = Spanish
+)
+
+var supported = map[string]bool{Spanish: true, English: true}
+
+// Negotiate elige el idioma soportado con mayor preferencia (q) en la cabecera
+// Accept-Language, p. ej. "en-US,en;q=0.9,es;q=0.8" → "en". Sin coincidencias usa Default.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		// Solo importa el idioma principal: es-MX → es
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		candidates = append(candidates, candidate{lang: base, q: q})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	for _, c := range candidates {
+		if supported[c.lang] {
+			return c.lang
+		}
+	}
+	return Default
+}
@@ -0,0 +1,36 @@
+// Package idempotency guarda la primera respuesta de cada petición con Idempotency-Key
+// para repetirla cuando el cliente reintenta.
+package idempotency
+
+import "time"
+
+// Record - Respuesta guardada para una clave de un usuario. StatusCode 0 indica que la
+// primera petición sigue en curso.
+type Record struct {
+	UserID      string
+	Key         string
+	Fingerprint string // hash del método, la ruta y el cuerpo de la petición
+	StatusCode  int
+	Headers     map[string]string
+	Body        []byte
+	LockedUntil time.Time // hasta cuándo se respeta una petición en curso
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// InProgress indica si la primera petición con esta clave aún no ha respondido
+func (r *Record) InProgress() bool {
+	return r.StatusCode == 0
+}
+
+type Store interface {
+	// Reserve guarda el registro en curso si la clave está libre (no existe, venció o la
+	// petición anterior quedó abandonada) y retorna nil; si no, retorna el registro existente
+	Reserve(record *Record, now time.Time) (*Record, error)
+	// Complete guarda la respuesta de un registro reservado
+	Complete(record *Record) error
+	// Release libera la clave para que se pueda reintentar (p. ej. tras un error del servidor)
+	Release(userID, key string) error
+	// DeleteExpired elimina los registros vencidos y retorna cuántos eran
+	DeleteExpired(now time.Time) (int64, error)
+}
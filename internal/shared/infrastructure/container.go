@@ -1,10 +1,18 @@
 package infrastructure
 
 import (
+	"context"
+	"errors"
+	"go-task-easy-list/config"
 	authConfig "go-task-easy-list/internal/auth/infrastructure/config"
 	"go-task-easy-list/internal/shared/infrastructure/middleware"
 	gormRepo "go-task-easy-list/internal/auth/infrastructure/persistence/gorm"
+	sharedGorm "go-task-easy-list/internal/shared/infrastructure/persistence/gorm"
+	"go-task-easy-list/internal/shared/infrastructure/worker"
+	"go-task-easy-list/internal/shared/notification"
 	taskConfig "go-task-easy-list/internal/tasks/infrastructure/config"
+	"log"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
@@ -13,21 +21,93 @@ import (
 type Container struct {
 	AuthModule     *authConfig.AuthModule
 	AuthMiddleware *middleware.AuthMiddleware
+	Idempotency    *middleware.Idempotency
 	TaskModule *taskConfig.TaskModule
+
+	// IdempotencyPurger elimina las respuestas guardadas que superan IDEMPOTENCY_TTL
+	IdempotencyPurger *worker.Worker
+	// SessionPurger elimina las sesiones caducadas y sus refresh tokens rotados
+	SessionPurger *worker.Worker
 }
 
-func NewContainer(db *gorm.DB, jwtSecret string) *Container {
+const (
+	// idempotencyPurgeInterval - Cada cuánto se eliminan las Idempotency-Key vencidas
+	idempotencyPurgeInterval = time.Hour
+	// sessionPurgeInterval - Cada cuánto se eliminan las sesiones caducadas
+	sessionPurgeInterval = time.Hour
+)
+
+func NewContainer(db *gorm.DB, cfg *config.Config) *Container {
 	sessionRepo := gormRepo.NewSessionRepository(db)
 
+	// Validadas en LoadConfig
+	durations, err := cfg.Durations()
+	if err != nil {
+		log.Fatal(err)
+	}
+	idempotencyStore := sharedGorm.NewIdempotencyStore(db)
+
+	// Validada en LoadConfig
+	sessionPolicy, err := cfg.SessionPolicy()
+	if err != nil {
+		log.Fatal(err)
+	}
+	signingKeys, err := cfg.JWTKeySet()
+	if err != nil {
+		log.Fatal("Error cargando las claves JWT: ", err)
+	}
+	log.Printf("JWT firmados con la clave %q", signingKeys.ActiveKID())
+
 	return &Container {
-		AuthModule: authConfig.NewAuthModule(db, jwtSecret),
-		AuthMiddleware: middleware.NewAuthMiddleware(jwtSecret, sessionRepo),
-		TaskModule: taskConfig.NewTaskModule(db),
+		AuthModule: authConfig.NewAuthModule(db, signingKeys, cfg.RefreshTokenSecret, sessionPolicy),
+		AuthMiddleware: middleware.NewAuthMiddleware(signingKeys, sessionRepo, sessionPolicy.AccessTokenTTL),
+		Idempotency: middleware.NewIdempotency(idempotencyStore, durations.IdempotencyTTL),
+		TaskModule: taskConfig.NewTaskModule(db, cfg.CursorSecret, newNotifiers(cfg), durations.ReminderInterval, cfg.TaskStatusWorkflow, durations.TrashRetention),
+		IdempotencyPurger: worker.New("idempotency", idempotencyPurgeInterval, func(ctx context.Context) error {
+			purged, err := idempotencyStore.DeleteExpired(time.Now())
+			if err == nil && purged > 0 {
+				log.Printf("[idempotency] %d claves vencidas eliminadas", purged)
+			}
+			return err
+		}),
+		SessionPurger: worker.New("sessions", sessionPurgeInterval, func(ctx context.Context) error {
+			return sessionRepo.DeleteExpired()
+		}),
+	}
+}
+
+// StartWorkers arranca los procesos en segundo plano de los módulos
+func (c *Container) StartWorkers() {
+	c.TaskModule.ReminderScheduler.Start()
+	c.TaskModule.TrashPurger.Start()
+	c.IdempotencyPurger.Start()
+	c.SessionPurger.Start()
+}
+
+// StopWorkers detiene los procesos en segundo plano esperando a que terminen como máximo hasta ctx
+func (c *Container) StopWorkers(ctx context.Context) error {
+	return errors.Join(
+		c.TaskModule.ReminderScheduler.Stop(ctx),
+		c.TaskModule.TrashPurger.Stop(ctx),
+		c.IdempotencyPurger.Stop(ctx),
+		c.SessionPurger.Stop(ctx),
+	)
+}
+
+// newNotifiers registra los canales de notificación disponibles según la configuración
+func newNotifiers(cfg *config.Config) map[string]notification.Notifier {
+	notifiers := map[string]notification.Notifier{
+		notification.ChannelLog:   notification.NewLogNotifier(),
+		notification.ChannelEmail: notification.NewSMTPNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom),
+	}
+	if cfg.WebhookURL != "" {
+		notifiers[notification.ChannelWebhook] = notification.NewWebhookNotifier(cfg.WebhookURL, cfg.WebhookSecret)
 	}
+	return notifiers
 }
 
 // RegisterRoutes registra las rutas de todos los módulos
 func (c *Container) RegisterRoutes(r chi.Router) {
-	c.AuthModule.RegisterRoutes(r, c.AuthMiddleware)
-	c.TaskModule.RegisterRoutes(r, c.AuthMiddleware)
+	c.AuthModule.RegisterRoutes(r, c.AuthMiddleware, c.Idempotency)
+	c.TaskModule.RegisterRoutes(r, c.AuthMiddleware, c.Idempotency)
 }
\ No newline at end of file
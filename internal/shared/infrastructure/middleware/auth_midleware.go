@@ -5,25 +5,32 @@ import (
 	"go-task-easy-list/internal/auth/domain/repository"
 	sharedhttp "go-task-easy-list/internal/shared/http"
 	sharedContext "go-task-easy-list/internal/shared/context"
+	"go-task-easy-list/internal/shared/jwtkeys"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type AuthMiddleware struct {
-	jwtSecret string
+	signingKeys *jwtkeys.KeySet
 	sessionRepo repository.SessionRepository
+	accessTokenTTL time.Duration
 }
 
-func NewAuthMiddleware(jwtSecret string, sessionRepo repository.SessionRepository) *AuthMiddleware {
+// accessTokenTTL limita la antigüedad de los access tokens aunque su exp sea posterior
+// (p. ej. emitidos antes de acortar JWT_ACCESS_EXPIRATION)
+func NewAuthMiddleware(signingKeys *jwtkeys.KeySet, sessionRepo repository.SessionRepository, accessTokenTTL time.Duration) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtSecret: jwtSecret,
+		signingKeys: signingKeys,
 		sessionRepo: sessionRepo,
+		accessTokenTTL: accessTokenTTL,
 	}
 }
 
-// RequireAuth valida el JWT y extrae el userId
+// RequireAuth valida el JWT y extrae el userId y la sesión (claim sid). Si la sesión
+// se cerró, sus access tokens dejan de valer aunque no hayan expirado.
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -41,12 +48,8 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 
 		tokenString := parts[1]
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(m.jwtSecret), nil
-		})
+		// La clave se resuelve por el kid de la cabecera; solo se aceptan los algoritmos de las claves configuradas
+		token, err := jwt.Parse(tokenString, m.signingKeys.Keyfunc, jwt.WithValidMethods(m.signingKeys.Methods()))
 
 		if err != nil || !token.Valid {
 			sharedhttp.ErrorResponse(w, http.StatusUnauthorized, "Token inválido o expirado")
@@ -59,19 +62,38 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 			return
 		}
 
+		issuedAt, err := claims.GetIssuedAt()
+		if err != nil || issuedAt == nil || time.Since(issuedAt.Time) > m.accessTokenTTL {
+			sharedhttp.ErrorResponse(w, http.StatusUnauthorized, "Token inválido o expirado")
+			return
+		}
+
 		userID, ok := claims["userId"].(string)
 		if !ok {
 			sharedhttp.ErrorResponse(w, http.StatusUnauthorized, "userId no encontrado en token")
 			return
 		}
 
-		hasSession, err := m.sessionRepo.HasActiveSession(userID)
-		if err != nil || !hasSession {
+		sessionID, _ := claims["sid"].(string)
+		if !m.hasActiveSession(userID, sessionID) {
 			sharedhttp.ErrorResponse(w, http.StatusUnauthorized, "Sesión inválida o expirada")
 			return
 		}
 
 		ctx := context.WithValue(r.Context(), sharedContext.UserIdKey, userID)
+		ctx = context.WithValue(ctx, sharedContext.SessionIdKey, sessionID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
-}
\ No newline at end of file
+}
+
+// hasActiveSession comprueba la sesión del token; los tokens sin sid (anteriores a
+// identificar la sesión) valen mientras el usuario tenga alguna sesión activa
+func (m *AuthMiddleware) hasActiveSession(userID, sessionID string) bool {
+	if sessionID == "" {
+		hasSession, err := m.sessionRepo.HasActiveSession(userID)
+		return err == nil && hasSession
+	}
+
+	session, err := m.sessionRepo.FindByID(sessionID)
+	return err == nil && session != nil && session.UserID == userID && session.IsValid()
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	sharedContext "go-task-easy-list/internal/shared/context"
+	sharedhttp "go-task-easy-list/internal/shared/http"
+	"go-task-easy-list/internal/shared/idempotency"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// IdempotencyKeyHeader - Cabecera con la que el cliente identifica una petición que puede reintentar
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyLockTimeout - Tiempo tras el cual una petición que no llegó a responder
+// (p. ej. por una caída del servidor) deja de bloquear su clave
+const idempotencyLockTimeout = time.Minute
+
+var validIdempotencyKey = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,255}$`)
+
+type Idempotency struct {
+	store  idempotency.Store
+	window time.Duration
+}
+
+func NewIdempotency(store idempotency.Store, window time.Duration) *Idempotency {
+	return &Idempotency{
+		store:  store,
+		window: window,
+	}
+}
+
+// Handle guarda la primera respuesta de cada POST con Idempotency-Key (por usuario) durante
+// la ventana configurada y la repite en los reintentos. Reutilizar la clave con otra
+// petición responde 422 y mientras la primera sigue en curso responde 409. Debe ir después
+// de RequireAuth para separar las claves por usuario.
+func (m *Idempotency) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !validIdempotencyKey.MatchString(key) {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, "Idempotency-Key inválida (hasta 255 caracteres alfanuméricos o . _ : -)")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, "No se pudo leer la petición")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		record := &idempotency.Record{
+			UserID:      sharedContext.GetUserID(r.Context()),
+			Key:         key,
+			Fingerprint: requestFingerprint(r, body),
+			LockedUntil: now.Add(idempotencyLockTimeout),
+			ExpiresAt:   now.Add(m.window),
+			CreatedAt:   now,
+		}
+
+		existing, err := m.store.Reserve(record, now)
+		if err != nil {
+			sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al procesar la Idempotency-Key")
+			return
+		}
+		if existing != nil {
+			m.replay(w, existing, record.Fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		// Los errores del servidor no se guardan: el cliente puede reintentar con la misma clave
+		if recorder.status() >= http.StatusInternalServerError {
+			if err := m.store.Release(record.UserID, record.Key); err != nil {
+				log.Printf("[idempotency] error liberando la clave %q: %v", key, err)
+			}
+			return
+		}
+
+		record.StatusCode = recorder.status()
+		record.Headers = recorder.savedHeaders()
+		record.Body = recorder.body.Bytes()
+		if err := m.store.Complete(record); err != nil {
+			log.Printf("[idempotency] error guardando la respuesta de la clave %q: %v", key, err)
+		}
+	})
+}
+
+// replay responde a un reintento con la respuesta guardada
+func (m *Idempotency) replay(w http.ResponseWriter, existing *idempotency.Record, fingerprint string) {
+	switch {
+	case existing.Fingerprint != fingerprint:
+		sharedhttp.ErrorResponse(w, http.StatusUnprocessableEntity, "La Idempotency-Key ya se usó con otra petición")
+	case existing.InProgress():
+		sharedhttp.ErrorResponse(w, http.StatusConflict, "Hay una petición en curso con la misma Idempotency-Key")
+	default:
+		for name, value := range existing.Headers {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(existing.StatusCode)
+		w.Write(existing.Body)
+	}
+}
+
+// requestFingerprint identifica la petición (método, ruta y cuerpo) para detectar
+// que se reutiliza una clave con otro contenido
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder responde al cliente y a la vez guarda una copia de la respuesta
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) status() int {
+	if r.statusCode == 0 {
+		return http.StatusOK
+	}
+	return r.statusCode
+}
+
+// savedHeaders - Cabeceras que se repiten en los reintentos (X-Request-Id es de cada petición)
+func (r *responseRecorder) savedHeaders() map[string]string {
+	headers := make(map[string]string)
+	for name, values := range r.ResponseWriter.Header() {
+		if name == RequestIDHeader || len(values) == 0 {
+			continue
+		}
+		headers[name] = values[0]
+	}
+	return headers
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	sharedContext "go-task-easy-list/internal/shared/context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader - Cabecera con la que el cliente puede enviar su propio identificador
+const RequestIDHeader = "X-Request-Id"
+
+// Se acepta el identificador del cliente solo si es corto y sin caracteres raros
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// RequestID asigna un identificador a cada petición (el de X-Request-Id o uno nuevo),
+// lo deja en el contexto y lo devuelve en la respuesta para poder correlacionarla
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		ctx := context.WithValue(r.Context(), sharedContext.RequestIdKey, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,135 @@
+package gorm
+
+import (
+	"encoding/json"
+	"go-task-easy-list/internal/shared/idempotency"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyModel - Respuesta guardada por usuario y Idempotency-Key
+type IdempotencyKeyModel struct {
+	UserID      string `gorm:"primaryKey;type:text"` // vacío en rutas sin autenticación
+	Key         string `gorm:"primaryKey;type:text;column:idempotency_key"`
+	Fingerprint string `gorm:"not null"`
+	StatusCode  int    `gorm:"not null;default:0"`
+	Headers     string // JSON
+	Body        []byte
+	LockedUntil time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+type IdempotencyStoreGorm struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyStore(db *gorm.DB) *IdempotencyStoreGorm {
+	return &IdempotencyStoreGorm{db: db}
+}
+
+func (s *IdempotencyStoreGorm) Reserve(record *idempotency.Record, now time.Time) (*idempotency.Record, error) {
+	recordModel, err := toIdempotencyKeyModel(record)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(recordModel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	// La clave ya existe: se reutiliza si venció o si la petición anterior quedó a medias
+	result = s.db.Model(&IdempotencyKeyModel{}).
+		Where("user_id = ? AND idempotency_key = ?", record.UserID, record.Key).
+		Where("expires_at < ? OR (status_code = 0 AND locked_until < ?)", now, now).
+		Select("*").Omit("user_id", "idempotency_key").
+		Updates(recordModel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var existing IdempotencyKeyModel
+	if err := s.db.First(&existing, "user_id = ? AND idempotency_key = ?", record.UserID, record.Key).Error; err != nil {
+		return nil, err
+	}
+	return toDomainIdempotencyRecord(&existing)
+}
+
+func (s *IdempotencyStoreGorm) Complete(record *idempotency.Record) error {
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Model(&IdempotencyKeyModel{}).
+		Where("user_id = ? AND idempotency_key = ?", record.UserID, record.Key).
+		Updates(map[string]interface{}{
+			"status_code": record.StatusCode,
+			"headers":     string(headers),
+			"body":        record.Body,
+		}).Error
+}
+
+func (s *IdempotencyStoreGorm) Release(userID, key string) error {
+	return s.db.Delete(&IdempotencyKeyModel{}, "user_id = ? AND idempotency_key = ?", userID, key).Error
+}
+
+func (s *IdempotencyStoreGorm) DeleteExpired(now time.Time) (int64, error) {
+	result := s.db.Where("expires_at < ?", now).Delete(&IdempotencyKeyModel{})
+	return result.RowsAffected, result.Error
+}
+
+// ------------------- Helper ---------------------
+
+func toIdempotencyKeyModel(record *idempotency.Record) (*IdempotencyKeyModel, error) {
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdempotencyKeyModel{
+		UserID:      record.UserID,
+		Key:         record.Key,
+		Fingerprint: record.Fingerprint,
+		StatusCode:  record.StatusCode,
+		Headers:     string(headers),
+		Body:        record.Body,
+		LockedUntil: record.LockedUntil,
+		ExpiresAt:   record.ExpiresAt,
+		CreatedAt:   record.CreatedAt,
+	}, nil
+}
+
+func toDomainIdempotencyRecord(m *IdempotencyKeyModel) (*idempotency.Record, error) {
+	var headers map[string]string
+	if m.Headers != "" {
+		if err := json.Unmarshal([]byte(m.Headers), &headers); err != nil {
+			return nil, err
+		}
+	}
+
+	return &idempotency.Record{
+		UserID:      m.UserID,
+		Key:         m.Key,
+		Fingerprint: m.Fingerprint,
+		StatusCode:  m.StatusCode,
+		Headers:     headers,
+		Body:        m.Body,
+		LockedUntil: m.LockedUntil,
+		ExpiresAt:   m.ExpiresAt,
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
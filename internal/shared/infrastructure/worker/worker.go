@@ -0,0 +1,66 @@
+// Package worker ejecuta tareas periódicas en segundo plano con parada ordenada.
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Job func(ctx context.Context) error
+
+type Worker struct {
+	name     string
+	interval time.Duration
+	job      Job
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func New(name string, interval time.Duration, job Job) *Worker {
+	return &Worker{name: name, interval: interval, job: job}
+}
+
+// Start lanza la goroutine: ejecuta el job al arrancar y luego cada intervalo
+func (w *Worker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := w.job(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("[%s] error: %v", w.name, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	log.Printf("[%s] iniciado (cada %s)", w.name, w.interval)
+}
+
+// Stop cancela el job en curso y espera a que termine o a que venza ctx
+func (w *Worker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		log.Printf("[%s] detenido", w.name)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,132 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnknownKey      = errors.New("clave de firma desconocida (kid)")
+	ErrUnsupportedKey  = errors.New("tipo de clave no soportado (usar RSA de al menos 2048 bits o Ed25519)")
+	ErrNoActiveKey     = errors.New("no hay clave activa para firmar")
+	ErrActiveNotSigner = errors.New("la clave activa no tiene parte privada")
+)
+
+// minRSABits - Tamaño mínimo aceptado para las claves RSA
+const minRSABits = 2048
+
+// Key - Clave de firma identificada por kid. Las claves retiradas pueden conservar solo
+// la parte pública para seguir verificando los tokens que firmaron.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer // nil si la clave solo sirve para verificar
+	Public  crypto.PublicKey
+}
+
+// NewKey construye la clave a partir de su parte privada o pública (RSA → RS256, Ed25519 → EdDSA)
+func NewKey(kid string, key any) (*Key, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return nil, ErrUnsupportedKey
+		}
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSABits {
+			return nil, ErrUnsupportedKey
+		}
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// GenerateEd25519 - Clave nueva en memoria (para desarrollo: no sobrevive a un reinicio)
+func GenerateEd25519(kid string) (*Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(kid, private)
+}
+
+// KeySet - Claves con las que se firman (la activa) y verifican (todas) los JWT.
+// Rotar consiste en añadir una clave nueva y activarla: las anteriores siguen
+// verificando los tokens ya emitidos hasta que se retiran.
+type KeySet struct {
+	keys   map[string]*Key
+	order  []string
+	active *Key
+}
+
+func NewKeySet(activeKID string, keys ...*Key) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*Key, len(keys))}
+	for _, key := range keys {
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("kid repetido %q", key.ID)
+		}
+		set.keys[key.ID] = key
+		set.order = append(set.order, key.ID)
+	}
+
+	active, ok := set.keys[activeKID]
+	if !ok {
+		return nil, ErrNoActiveKey
+	}
+	if active.Private == nil {
+		return nil, ErrActiveNotSigner
+	}
+	set.active = active
+	return set, nil
+}
+
+// ActiveKID - kid con el que se firman los tokens nuevos
+func (s *KeySet) ActiveKID() string {
+	return s.active.ID
+}
+
+// Sign firma los claims con la clave activa e indica su kid en la cabecera
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.active.Method, claims)
+	token.Header["kid"] = s.active.ID
+	return token.SignedString(s.active.Private)
+}
+
+// Keyfunc resuelve la clave de verificación por el kid del token, comprobando que el
+// algoritmo sea el de esa clave (así no se acepta un token firmado con otro método)
+func (s *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	return key.Public, nil
+}
+
+// Methods - Algoritmos de las claves del conjunto, para jwt.WithValidMethods
+func (s *KeySet) Methods() []string {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, kid := range s.order {
+		alg := s.keys[kid].Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
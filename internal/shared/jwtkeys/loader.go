@@ -0,0 +1,75 @@
+package jwtkeys
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadDir carga las claves PEM del directorio; el kid es el nombre del fichero sin
+// extensión (2026-01.pem → "2026-01"). Admite claves privadas (PKCS#8 o PKCS#1) y
+// públicas (PKIX) de claves retiradas. activeKID elige la que firma; si está vacío
+// tiene que haber una sola clave privada.
+func LoadDir(dir, activeKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no hay claves .pem en %s", dir)
+	}
+
+	keys := make([]*Key, 0, len(paths))
+	var signers []string
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		key, err := loadKey(path, kid)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if key.Private != nil {
+			signers = append(signers, kid)
+		}
+		keys = append(keys, key)
+	}
+
+	if activeKID == "" {
+		if len(signers) != 1 {
+			return nil, fmt.Errorf("hay %d claves privadas en %s: indicar cuál firma con JWT_ACTIVE_KID", len(signers), dir)
+		}
+		activeKID = signers[0]
+	}
+	return NewKeySet(activeKID, keys...)
+}
+
+func loadKey(path, kid string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no es un fichero PEM")
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("bloque PEM %q no soportado", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(kid, parsed)
+}
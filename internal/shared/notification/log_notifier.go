@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier escribe el aviso en el log del servidor (útil en desarrollo)
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Send(ctx context.Context, msg Message) error {
+	log.Printf("[notificación] para=%s asunto=%q %s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
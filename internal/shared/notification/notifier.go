@@ -0,0 +1,23 @@
+// Package notification define los canales por los que se envían avisos a los usuarios.
+package notification
+
+import "context"
+
+// Canales disponibles
+const (
+	ChannelLog     = "log"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+type Message struct {
+	ID      string            `json:"id"` // identificador estable: permite al receptor descartar duplicados
+	To      string            `json:"to"`
+	Subject string            `json:"subject"`
+	Body    string            `json:"body"`
+	Data    map[string]string `json:"data,omitempty"`
+}
+
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
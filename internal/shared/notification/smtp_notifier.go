@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier envía el aviso por email. En desarrollo puede apuntar a un
+// servidor local de pruebas como MailHog o Mailpit (localhost:1025).
+type SMTPNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPNotifier(host, port, username, password, from string) *SMTPNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPNotifier{addr: net.JoinHostPort(host, port), from: from, auth: auth}
+}
+
+func (n *SMTPNotifier) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("el destinatario no tiene email")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	headers := []string{
+		"From: " + n.from,
+		"To: " + msg.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	if msg.ID != "" {
+		headers = append(headers, fmt.Sprintf("Message-ID: <%s@%s>", msg.ID, hostOf(n.from)))
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + msg.Body + "\r\n"
+
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{msg.To}, []byte(body))
+}
+
+func hostOf(email string) string {
+	if _, host, ok := strings.Cut(email, "@"); ok {
+		return host
+	}
+	return "localhost"
+}
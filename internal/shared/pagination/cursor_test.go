@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPayload struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	codec := NewCursorCodec("secreto")
+	want := testPayload{CreatedAt: time.Date(2026, time.January, 5, 9, 30, 0, 0, time.UTC), ID: "t1"}
+
+	cursor, err := codec.Encode(want)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	var got testPayload
+	if err := codec.Decode(cursor, &got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Errorf("Decode = %+v, se esperaba %+v", got, want)
+	}
+}
+
+func TestCursorRejectsTampering(t *testing.T) {
+	codec := NewCursorCodec("secreto")
+	cursor, err := codec.Encode(testPayload{ID: "t1"})
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	encoded, signature, _ := strings.Cut(cursor, ".")
+
+	// Un payload distinto firmado con la clave correcta, para reutilizar su firma
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"i":"t2"}`))
+	// Firmas válidas sobre contenido que no es un payload
+	notBase64 := "%%%"
+	notJSON := base64.RawURLEncoding.EncodeToString([]byte("no es json"))
+	otherCodec := NewCursorCodec("otro-secreto")
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "vacío", cursor: ""},
+		{name: "sin firma", cursor: encoded},
+		{name: "firma vacía", cursor: encoded + "."},
+		{name: "firma alterada", cursor: encoded + "." + strings.ToUpper(signature)},
+		{name: "payload alterado con la firma original", cursor: forged + "." + signature},
+		{name: "firmado con otro secreto", cursor: encoded + "." + otherCodec.sign(encoded)},
+		{name: "payload que no es base64", cursor: notBase64 + "." + codec.sign(notBase64)},
+		{name: "payload que no es JSON", cursor: notJSON + "." + codec.sign(notJSON)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload testPayload
+			if err := codec.Decode(tt.cursor, &payload); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode(%q): err = %v, se esperaba ErrInvalidCursor", tt.cursor, err)
+			}
+		})
+	}
+}
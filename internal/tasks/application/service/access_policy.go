@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/repository"
+)
+
+var ErrForbidden = errors.New("no tienes permisos suficientes para esta acción")
+
+// Action - Operación que se quiere realizar sobre una tarea o proyecto
+type Action int
+
+const (
+	ActionRead   Action = iota // ver
+	ActionWrite                // crear, editar, eliminar tareas
+	ActionManage               // editar/eliminar el proyecto y gestionar miembros
+)
+
+// AccessPolicy centraliza las reglas de autorización sobre tareas y proyectos.
+//
+//   - Tarea sin proyecto: solo su creador puede verla y modificarla.
+//   - Tarea en un proyecto: decide el rol del usuario en el proyecto.
+//   - owner: todo; editor: leer y escribir; viewer: solo leer.
+//   - El creador del proyecto es siempre owner.
+type AccessPolicy struct {
+	projectRepo repository.ProjectRepository
+	memberRepo  repository.ProjectMemberRepository
+}
+
+func NewAccessPolicy(projectRepo repository.ProjectRepository, memberRepo repository.ProjectMemberRepository) *AccessPolicy {
+	return &AccessPolicy{projectRepo: projectRepo, memberRepo: memberRepo}
+}
+
+// AuthorizeTask retorna ErrUnauthorized si el usuario no tiene acceso a la tarea
+// y ErrForbidden si tiene acceso pero su rol no permite la acción.
+func (p *AccessPolicy) AuthorizeTask(task *model.Task, userID string, action Action) error {
+	if task.ProjectID == "" {
+		if task.UserID != userID {
+			return ErrUnauthorized
+		}
+		return nil
+	}
+
+	project, err := p.projectRepo.FindByID(task.ProjectID)
+	if err != nil || project == nil {
+		return ErrUnauthorized
+	}
+
+	role, err := p.ProjectRole(project, userID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return ErrUnauthorized
+	}
+	if !roleAllows(role, action) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// AuthorizeProject retorna ErrProjectNotFound si el usuario no es miembro
+// y ErrForbidden si su rol no permite la acción.
+func (p *AccessPolicy) AuthorizeProject(project *model.Project, userID string, action Action) error {
+	role, err := p.ProjectRole(project, userID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return ErrProjectNotFound
+	}
+	if !roleAllows(role, action) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// ProjectRole - Rol del usuario en el proyecto ("" si no tiene acceso)
+func (p *AccessPolicy) ProjectRole(project *model.Project, userID string) (model.ProjectRole, error) {
+	if project.UserID == userID {
+		return model.RoleOwner, nil
+	}
+	return p.memberRepo.FindRole(project.ID, userID)
+}
+
+func roleAllows(role model.ProjectRole, action Action) bool {
+	switch role {
+	case model.RoleOwner:
+		return true
+	case model.RoleEditor:
+		return action == ActionRead || action == ActionWrite
+	case model.RoleViewer:
+		return action == ActionRead
+	default:
+		return false
+	}
+}
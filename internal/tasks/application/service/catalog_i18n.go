@@ -0,0 +1,58 @@
+package service
+
+import (
+	"go-task-easy-list/internal/shared/i18n"
+	"go-task-easy-list/internal/tasks/domain/model"
+)
+
+type catalogText struct {
+	Name        string
+	Description string
+}
+
+// Traducciones de las entradas del sistema por código. Las personalizadas se
+// muestran tal como las nombró el usuario.
+var statusTranslations = map[string]map[string]catalogText{
+	i18n.Spanish: {
+		"PENDING":     {"Pendiente", "Tarea pendiente por iniciar"},
+		"IN_PROGRESS": {"En Progreso", "Tarea en proceso"},
+		"COMPLETED":   {"Completada", "Tarea finalizada"},
+	},
+	i18n.English: {
+		"PENDING":     {"Pending", "Task not started yet"},
+		"IN_PROGRESS": {"In Progress", "Task in progress"},
+		"COMPLETED":   {"Completed", "Task finished"},
+	},
+}
+
+var priorityTranslations = map[string]map[string]catalogText{
+	i18n.Spanish: {
+		"LOW":    {Name: "Baja"},
+		"MEDIUM": {Name: "Media"},
+		"HIGH":   {Name: "Alta"},
+	},
+	i18n.English: {
+		"LOW":    {Name: "Low"},
+		"MEDIUM": {Name: "Medium"},
+		"HIGH":   {Name: "High"},
+	},
+}
+
+// localizeStatus retorna una copia del estado con nombre y descripción en lang
+func localizeStatus(status *model.TaskStatus, lang string) *model.TaskStatus {
+	localized := *status
+	if text, ok := statusTranslations[lang][status.Code]; ok && status.Code != "" {
+		localized.Name = text.Name
+		localized.Description = text.Description
+	}
+	return &localized
+}
+
+// localizePriority retorna una copia de la prioridad con el nombre en lang
+func localizePriority(priority *model.TaskPriority, lang string) *model.TaskPriority {
+	localized := *priority
+	if text, ok := priorityTranslations[lang][priority.Code]; ok && priority.Code != "" {
+		localized.Name = text.Name
+	}
+	return &localized
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/repository"
+	"strings"
+	"time"
+)
+
+// Errores del dominio
+var (
+	ErrInvalidPriority    = errors.New("prioridad de tarea inválida")
+	ErrStatusNotFound     = errors.New("estado no encontrado")
+	ErrPriorityNotFound   = errors.New("prioridad no encontrada")
+	ErrInvalidCatalogName = errors.New("el nombre no puede estar vacío")
+	ErrCatalogNameExists  = errors.New("ya existe una entrada del catálogo con ese nombre")
+	ErrInvalidCategory    = errors.New("categoría inválida, usar open o done")
+	ErrCategoryChange     = errors.New("no se puede cambiar la categoría de un estado; crea otro y elimina este reasignando sus tareas")
+	ErrInvalidLevel       = errors.New("el nivel de prioridad debe ser mayor que cero")
+	ErrSystemCatalog      = errors.New("los estados y prioridades del sistema no se pueden modificar")
+	ErrInvalidReassign    = errors.New("el reemplazo debe ser otra entrada disponible en el mismo ámbito (y, para estados, de la misma categoría)")
+)
+
+// CatalogService gestiona los estados y prioridades personalizados. Los del sistema
+// son de solo lectura; los de un usuario aplican a sus tareas sin proyecto y los de un
+// proyecto a las tareas del proyecto (solo su owner puede gestionarlos). UserID guarda
+// siempre quién creó la entrada.
+type CatalogService struct {
+	catalogRepo repository.CatalogRepository
+	projectRepo repository.ProjectRepository
+	policy      *AccessPolicy
+}
+
+func NewCatalogService(
+	catalogRepo repository.CatalogRepository,
+	projectRepo repository.ProjectRepository,
+	policy *AccessPolicy,
+) *CatalogService {
+	return &CatalogService{catalogRepo: catalogRepo, projectRepo: projectRepo, policy: policy}
+}
+
+// GetStatuses - Estados del sistema (traducidos a lang) más los del usuario o,
+// si se indica, los del proyecto
+func (s *CatalogService) GetStatuses(userID, projectID, lang string) ([]*model.TaskStatus, error) {
+	scope, err := s.authorizeScope(userID, projectID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.catalogRepo.FindStatuses(scope)
+	if err != nil {
+		return nil, err
+	}
+	for i, status := range statuses {
+		statuses[i] = localizeStatus(status, lang)
+	}
+	return statuses, nil
+}
+
+func (s *CatalogService) CreateStatus(status *model.TaskStatus, userID string) (*model.TaskStatus, error) {
+	scope, err := s.authorizeScope(userID, status.ProjectID, ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Category == "" {
+		status.Category = model.StatusCategoryOpen
+	}
+	if !status.Category.IsValid() {
+		return nil, ErrInvalidCategory
+	}
+
+	name, err := s.validateStatusName(status.Name, scope, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	newStatus := &model.TaskStatus{
+		Name:        name,
+		Description: strings.TrimSpace(status.Description),
+		Category:    status.Category,
+		UserID:      userID,
+		ProjectID:   scope.ProjectID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.catalogRepo.CreateStatus(newStatus); err != nil {
+		return nil, err
+	}
+	return newStatus, nil
+}
+
+func (s *CatalogService) UpdateStatus(id int, changes *model.TaskStatus, userID string) (*model.TaskStatus, error) {
+	status, err := s.findStatus(id, userID, ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes.Category != "" && changes.Category != status.Category {
+		return nil, ErrCategoryChange
+	}
+
+	name, err := s.validateStatusName(changes.Name, entryScope(status.UserID, status.ProjectID), status.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status.Name = name
+	status.Description = strings.TrimSpace(changes.Description)
+	if err := s.catalogRepo.UpdateStatus(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// DeleteStatus - Elimina el estado pasando sus tareas a reassignTo; si no se indica,
+// se usa el estado del sistema de la misma categoría
+func (s *CatalogService) DeleteStatus(id, reassignTo int, userID string) error {
+	status, err := s.findStatus(id, userID, ActionManage)
+	if err != nil {
+		return err
+	}
+
+	if reassignTo == 0 {
+		reassignTo = model.DefaultStatusFor(status.Category)
+	}
+	target, err := s.catalogRepo.FindStatusByID(reassignTo)
+	if err != nil || target == nil || target.ID == status.ID || target.Category != status.Category ||
+		!entryScope(status.UserID, status.ProjectID).Contains(target.UserID, target.ProjectID) {
+		return ErrInvalidReassign
+	}
+
+	return s.catalogRepo.DeleteStatus(status.ID, target.ID)
+}
+
+// GetPriorities - Prioridades del sistema (traducidas a lang) más las del usuario o,
+// si se indica, las del proyecto
+func (s *CatalogService) GetPriorities(userID, projectID, lang string) ([]*model.TaskPriority, error) {
+	scope, err := s.authorizeScope(userID, projectID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities, err := s.catalogRepo.FindPriorities(scope)
+	if err != nil {
+		return nil, err
+	}
+	for i, priority := range priorities {
+		priorities[i] = localizePriority(priority, lang)
+	}
+	return priorities, nil
+}
+
+func (s *CatalogService) CreatePriority(priority *model.TaskPriority, userID string) (*model.TaskPriority, error) {
+	scope, err := s.authorizeScope(userID, priority.ProjectID, ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if priority.Level < 1 {
+		return nil, ErrInvalidLevel
+	}
+	name, err := s.validatePriorityName(priority.Name, scope, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	newPriority := &model.TaskPriority{
+		Name:      name,
+		Level:     priority.Level,
+		UserID:    userID,
+		ProjectID: scope.ProjectID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.catalogRepo.CreatePriority(newPriority); err != nil {
+		return nil, err
+	}
+	return newPriority, nil
+}
+
+func (s *CatalogService) UpdatePriority(id int, changes *model.TaskPriority, userID string) (*model.TaskPriority, error) {
+	priority, err := s.findPriority(id, userID, ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes.Level < 1 {
+		return nil, ErrInvalidLevel
+	}
+	name, err := s.validatePriorityName(changes.Name, entryScope(priority.UserID, priority.ProjectID), priority.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	priority.Name = name
+	priority.Level = changes.Level
+	if err := s.catalogRepo.UpdatePriority(priority); err != nil {
+		return nil, err
+	}
+	return priority, nil
+}
+
+// DeletePriority - Elimina la prioridad pasando sus tareas a reassignTo; si no se indica,
+// se usa la prioridad del sistema de nivel más cercano
+func (s *CatalogService) DeletePriority(id, reassignTo int, userID string) error {
+	priority, err := s.findPriority(id, userID, ActionManage)
+	if err != nil {
+		return err
+	}
+
+	if reassignTo == 0 {
+		if reassignTo, err = s.closestSystemPriority(priority.Level); err != nil {
+			return err
+		}
+	}
+	target, err := s.catalogRepo.FindPriorityByID(reassignTo)
+	if err != nil || target == nil || target.ID == priority.ID ||
+		!entryScope(priority.UserID, priority.ProjectID).Contains(target.UserID, target.ProjectID) {
+		return ErrInvalidReassign
+	}
+
+	return s.catalogRepo.DeletePriority(priority.ID, target.ID)
+}
+
+// --------------------- Helpers ---------------------
+
+// authorizeScope resuelve el ámbito pedido: el del usuario o el de un proyecto
+// sobre el que tenga el permiso indicado
+func (s *CatalogService) authorizeScope(userID, projectID string, action Action) (model.CatalogScope, error) {
+	if projectID == "" {
+		return model.CatalogScope{UserID: userID}, nil
+	}
+
+	project, err := s.projectRepo.FindByID(projectID)
+	if err != nil || project == nil {
+		return model.CatalogScope{}, ErrProjectNotFound
+	}
+	if err := s.policy.AuthorizeProject(project, userID, action); err != nil {
+		return model.CatalogScope{}, err
+	}
+	return model.CatalogScope{ProjectID: projectID}, nil
+}
+
+// findStatus obtiene un estado personalizado que el usuario puede gestionar
+func (s *CatalogService) findStatus(id int, userID string, action Action) (*model.TaskStatus, error) {
+	status, err := s.catalogRepo.FindStatusByID(id)
+	if err != nil || status == nil {
+		return nil, ErrStatusNotFound
+	}
+	if err := s.authorizeEntry(status.UserID, status.ProjectID, userID, action); err != nil {
+		if err == ErrProjectNotFound {
+			return nil, ErrStatusNotFound
+		}
+		return nil, err
+	}
+	return status, nil
+}
+
+// findPriority obtiene una prioridad personalizada que el usuario puede gestionar
+func (s *CatalogService) findPriority(id int, userID string, action Action) (*model.TaskPriority, error) {
+	priority, err := s.catalogRepo.FindPriorityByID(id)
+	if err != nil || priority == nil {
+		return nil, ErrPriorityNotFound
+	}
+	if err := s.authorizeEntry(priority.UserID, priority.ProjectID, userID, action); err != nil {
+		if err == ErrProjectNotFound {
+			return nil, ErrPriorityNotFound
+		}
+		return nil, err
+	}
+	return priority, nil
+}
+
+// authorizeEntry aplica los permisos según el dueño de la entrada del catálogo
+func (s *CatalogService) authorizeEntry(ownerID, projectID, userID string, action Action) error {
+	switch {
+	case ownerID == "" && projectID == "":
+		return ErrSystemCatalog
+	case projectID != "":
+		_, err := s.authorizeScope(userID, projectID, action)
+		return err
+	case ownerID != userID:
+		return ErrProjectNotFound
+	default:
+		return nil
+	}
+}
+
+// validateStatusName normaliza el nombre y verifica que no se repita en el ámbito
+func (s *CatalogService) validateStatusName(name string, scope model.CatalogScope, currentID int) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrInvalidCatalogName
+	}
+
+	statuses, err := s.catalogRepo.FindStatuses(scope)
+	if err != nil {
+		return "", err
+	}
+	for _, status := range statuses {
+		if status.ID != currentID && strings.EqualFold(status.Name, name) {
+			return "", ErrCatalogNameExists
+		}
+	}
+	return name, nil
+}
+
+// validatePriorityName normaliza el nombre y verifica que no se repita en el ámbito
+func (s *CatalogService) validatePriorityName(name string, scope model.CatalogScope, currentID int) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrInvalidCatalogName
+	}
+
+	priorities, err := s.catalogRepo.FindPriorities(scope)
+	if err != nil {
+		return "", err
+	}
+	for _, priority := range priorities {
+		if priority.ID != currentID && strings.EqualFold(priority.Name, name) {
+			return "", ErrCatalogNameExists
+		}
+	}
+	return name, nil
+}
+
+// closestSystemPriority - Prioridad del sistema con el nivel más parecido
+func (s *CatalogService) closestSystemPriority(level int) (int, error) {
+	priorities, err := s.catalogRepo.FindPriorities(model.CatalogScope{})
+	if err != nil {
+		return 0, err
+	}
+
+	closest, distance := model.PriorityMedium, -1
+	for _, priority := range priorities {
+		d := priority.Level - level
+		if d < 0 {
+			d = -d
+		}
+		if distance < 0 || d < distance {
+			closest, distance = priority.ID, d
+		}
+	}
+	return closest, nil
+}
+
+// entryScope - Ámbito en el que está disponible una entrada según su dueño
+func entryScope(ownerID, projectID string) model.CatalogScope {
+	if projectID != "" {
+		return model.CatalogScope{ProjectID: projectID}
+	}
+	return model.CatalogScope{UserID: ownerID}
+}
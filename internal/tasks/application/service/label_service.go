@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/repository"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errores del dominio
+var (
+	ErrInvalidLabelName = errors.New("el nombre de la etiqueta no puede estar vacío")
+	ErrInvalidColor     = errors.New("el color debe tener formato hexadecimal #RRGGBB")
+	ErrLabelNotFound    = errors.New("etiqueta no encontrada")
+	ErrLabelExists      = errors.New("ya existe una etiqueta con ese nombre")
+)
+
+const DefaultLabelColor = "#808080"
+
+var hexColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type LabelService struct {
+	labelRepo repository.LabelRepository
+	taskRepo  repository.TaskRepository
+	policy    *AccessPolicy
+}
+
+func NewLabelService(labelRepo repository.LabelRepository, taskRepo repository.TaskRepository, policy *AccessPolicy) *LabelService {
+	return &LabelService{labelRepo: labelRepo, taskRepo: taskRepo, policy: policy}
+}
+
+func (s *LabelService) CreateLabel(name, color, userID string) (*model.Label, error) {
+	name, color, err := s.validateLabel(name, color, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	label := &model.Label{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Color:     color,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+func (s *LabelService) GetLabels(userID string) ([]*model.Label, error) {
+	return s.labelRepo.FindByUserID(userID)
+}
+
+func (s *LabelService) GetLabelByID(id, userID string) (*model.Label, error) {
+	label, err := s.labelRepo.FindByID(id)
+	if err != nil || label == nil || label.UserID != userID {
+		return nil, ErrLabelNotFound
+	}
+	return label, nil
+}
+
+func (s *LabelService) UpdateLabel(id, name, color, userID string) (*model.Label, error) {
+	label, err := s.GetLabelByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, color, err = s.validateLabel(name, color, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	label.Name = name
+	label.Color = color
+	label.UpdatedAt = time.Now()
+
+	if err := s.labelRepo.Update(label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// DeleteLabel - Elimina la etiqueta y la desasigna de todas las tareas
+func (s *LabelService) DeleteLabel(id, userID string) error {
+	if _, err := s.GetLabelByID(id, userID); err != nil {
+		return err
+	}
+	return s.labelRepo.Delete(id)
+}
+
+// AssignLabels - Asigna etiquetas del usuario a una tarea en la que puede escribir
+func (s *LabelService) AssignLabels(taskID string, labelIDs []string, userID string) ([]*model.Label, error) {
+	if err := s.checkTaskWriteAccess(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	for _, labelID := range labelIDs {
+		if _, err := s.GetLabelByID(labelID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.labelRepo.AssignToTask(taskID, labelIDs); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.labelRepo.FindByTaskIDs([]string{taskID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	return labels[taskID], nil
+}
+
+// RemoveLabel - Quita una etiqueta de una tarea
+func (s *LabelService) RemoveLabel(taskID, labelID, userID string) error {
+	if err := s.checkTaskWriteAccess(taskID, userID); err != nil {
+		return err
+	}
+	if _, err := s.GetLabelByID(labelID, userID); err != nil {
+		return err
+	}
+	return s.labelRepo.RemoveFromTask(taskID, labelID)
+}
+
+// --------------------- Helpers ---------------------
+func (s *LabelService) checkTaskWriteAccess(taskID, userID string) error {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil || task == nil {
+		return ErrTaskNotFound
+	}
+	return s.policy.AuthorizeTask(task, userID, ActionWrite)
+}
+
+// validateLabel normaliza nombre y color y verifica que el nombre no esté repetido
+func (s *LabelService) validateLabel(name, color, userID, currentID string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", ErrInvalidLabelName
+	}
+
+	if color == "" {
+		color = DefaultLabelColor
+	}
+	if !hexColorRegex.MatchString(color) {
+		return "", "", ErrInvalidColor
+	}
+
+	existing, _ := s.labelRepo.FindByUserAndName(userID, name)
+	if existing != nil && existing.ID != currentID {
+		return "", "", ErrLabelExists
+	}
+
+	return name, strings.ToUpper(color), nil
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errores del dominio
+var (
+	ErrInvalidProjectName = errors.New("el nombre del proyecto no puede estar vacío")
+	ErrProjectNotFound    = errors.New("proyecto no encontrado")
+	ErrInvalidPosition    = errors.New("la posición no puede ser negativa")
+	ErrInvalidRole        = errors.New("rol inválido, usar owner, editor o viewer")
+	ErrMemberUserNotFound = errors.New("no existe un usuario activo con ese email")
+	ErrMemberNotFound     = errors.New("el usuario no es miembro del proyecto")
+	ErrProjectCreatorRole = errors.New("no se puede cambiar el rol ni quitar al creador del proyecto")
+)
+
+const DefaultProjectColor = "#4A90E2"
+
+type ProjectService struct {
+	projectRepo   repository.ProjectRepository
+	memberRepo    repository.ProjectMemberRepository
+	userDirectory repository.UserDirectory
+	policy        *AccessPolicy
+}
+
+func NewProjectService(
+	projectRepo repository.ProjectRepository,
+	memberRepo repository.ProjectMemberRepository,
+	userDirectory repository.UserDirectory,
+	policy *AccessPolicy,
+) *ProjectService {
+	return &ProjectService{
+		projectRepo:   projectRepo,
+		memberRepo:    memberRepo,
+		userDirectory: userDirectory,
+		policy:        policy,
+	}
+}
+
+func (s *ProjectService) CreateProject(project *model.Project, userID string) (*model.Project, error) {
+	if err := normalizeProject(project); err != nil {
+		return nil, err
+	}
+
+	position, err := s.projectRepo.NextPosition(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	newProject := &model.Project{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        project.Name,
+		Description: project.Description,
+		Color:       project.Color,
+		Position:    position,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		Role:        model.RoleOwner,
+	}
+
+	if err := s.projectRepo.Create(newProject); err != nil {
+		return nil, err
+	}
+	return newProject, nil
+}
+
+// GetProjects - Proyectos propios y compartidos con el usuario, con su rol en cada uno
+func (s *ProjectService) GetProjects(userID string, includeArchived bool) ([]*model.Project, error) {
+	projects, err := s.projectRepo.FindByUserID(userID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.memberRepo.FindRolesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if project.UserID == userID {
+			project.Role = model.RoleOwner
+		} else {
+			project.Role = roles[project.ID]
+		}
+	}
+	return projects, nil
+}
+
+func (s *ProjectService) GetProjectByID(id, userID string) (*model.Project, error) {
+	return s.findAuthorizedProject(id, userID, ActionRead)
+}
+
+func (s *ProjectService) UpdateProject(updatedProject *model.Project, userID string) (*model.Project, error) {
+	project, err := s.findAuthorizedProject(updatedProject.ID, userID, ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalizeProject(updatedProject); err != nil {
+		return nil, err
+	}
+	if updatedProject.Position < 0 {
+		return nil, ErrInvalidPosition
+	}
+
+	project.Name = updatedProject.Name
+	project.Description = updatedProject.Description
+	project.Color = updatedProject.Color
+	project.Archived = updatedProject.Archived
+	project.Position = updatedProject.Position
+	project.UpdatedAt = time.Now()
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject - Elimina el proyecto; sus tareas se conservan sin proyecto
+func (s *ProjectService) DeleteProject(id, userID string) error {
+	if _, err := s.findAuthorizedProject(id, userID, ActionManage); err != nil {
+		return err
+	}
+	return s.projectRepo.Delete(id)
+}
+
+// GetMembers - Miembros del proyecto, empezando por su creador
+func (s *ProjectService) GetMembers(projectID, userID string) ([]*model.ProjectMember, error) {
+	project, err := s.findAuthorizedProject(projectID, userID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	invited, err := s.memberRepo.FindByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := append([]*model.ProjectMember{{
+		ProjectID: project.ID,
+		UserID:    project.UserID,
+		Role:      model.RoleOwner,
+		CreatedAt: project.CreatedAt,
+	}}, invited...)
+
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	users, err := s.userDirectory.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if user, ok := users[member.UserID]; ok {
+			member.Email = user.Email
+			member.Name = user.Name
+		}
+	}
+	return members, nil
+}
+
+// InviteMember - Agrega por email a un usuario registrado con el rol indicado
+// (si ya era miembro, se actualiza su rol). Solo owners.
+func (s *ProjectService) InviteMember(projectID, email string, role model.ProjectRole, userID string) (*model.ProjectMember, error) {
+	project, err := s.findAuthorizedProject(projectID, userID, ActionManage)
+	if err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := s.userDirectory.FindByEmail(strings.TrimSpace(email))
+	if err != nil || user == nil {
+		return nil, ErrMemberUserNotFound
+	}
+	if user.ID == project.UserID {
+		return nil, ErrProjectCreatorRole
+	}
+
+	member := &model.ProjectMember{
+		ProjectID: projectID,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      role,
+		CreatedAt: time.Now(),
+	}
+	if err := s.memberRepo.Save(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// UpdateMemberRole - Cambia el rol de un miembro. Solo owners.
+func (s *ProjectService) UpdateMemberRole(projectID, memberUserID string, role model.ProjectRole, userID string) (*model.ProjectMember, error) {
+	project, err := s.findAuthorizedProject(projectID, userID, ActionManage)
+	if err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if memberUserID == project.UserID {
+		return nil, ErrProjectCreatorRole
+	}
+
+	current, err := s.memberRepo.FindRole(projectID, memberUserID)
+	if err != nil {
+		return nil, err
+	}
+	if current == "" {
+		return nil, ErrMemberNotFound
+	}
+
+	member := &model.ProjectMember{ProjectID: projectID, UserID: memberUserID, Role: role}
+	if err := s.memberRepo.Save(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember - Quita a un miembro del proyecto. Los owners pueden quitar a
+// cualquiera (salvo al creador) y cualquier miembro puede salir por sí mismo.
+func (s *ProjectService) RemoveMember(projectID, memberUserID, userID string) error {
+	action := ActionManage
+	if memberUserID == userID {
+		action = ActionRead
+	}
+
+	project, err := s.findAuthorizedProject(projectID, userID, action)
+	if err != nil {
+		return err
+	}
+	if memberUserID == project.UserID {
+		return ErrProjectCreatorRole
+	}
+
+	current, err := s.memberRepo.FindRole(projectID, memberUserID)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return ErrMemberNotFound
+	}
+
+	return s.memberRepo.Remove(projectID, memberUserID)
+}
+
+// --------------------- Helpers ---------------------
+
+// findAuthorizedProject obtiene el proyecto con el rol del usuario, verificando la acción con la política de acceso
+func (s *ProjectService) findAuthorizedProject(id, userID string, action Action) (*model.Project, error) {
+	project, err := s.projectRepo.FindByID(id)
+	if err != nil || project == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	if err := s.policy.AuthorizeProject(project, userID, action); err != nil {
+		return nil, err
+	}
+
+	if project.Role, err = s.policy.ProjectRole(project, userID); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+func normalizeProject(project *model.Project) error {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return ErrInvalidProjectName
+	}
+
+	if project.Color == "" {
+		project.Color = DefaultProjectColor
+	}
+	if !hexColorRegex.MatchString(project.Color) {
+		return ErrInvalidColor
+	}
+	project.Color = strings.ToUpper(project.Color)
+	return nil
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-task-easy-list/internal/shared/notification"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/repository"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReminderNotFound     = errors.New("recordatorio no encontrado")
+	ErrInvalidReminder      = errors.New("indicar offsetMinutes (minutos antes del vencimiento) o remindAt, no ambos")
+	ErrReminderNeedsDueDate = errors.New("un recordatorio relativo necesita que la tarea tenga fecha de vencimiento")
+	ErrReminderInPast       = errors.New("el recordatorio debe quedar en el futuro")
+	ErrInvalidChannel       = errors.New("canal de notificación no disponible")
+)
+
+const (
+	DefaultReminderChannel = notification.ChannelLog
+	MaxReminderAttempts    = 5
+
+	reminderBatchSize = 50
+	// reminderLease - tiempo que un envío queda reservado; si el proceso cae, otro lo retoma al vencer
+	reminderLease = 2 * time.Minute
+)
+
+type ReminderService struct {
+	reminderRepo  repository.ReminderRepository
+	taskRepo      repository.TaskRepository
+	userDirectory repository.UserDirectory
+	catalogRepo   repository.CatalogRepository
+	policy        *AccessPolicy
+	notifiers     map[string]notification.Notifier
+}
+
+func NewReminderService(
+	reminderRepo repository.ReminderRepository,
+	taskRepo repository.TaskRepository,
+	userDirectory repository.UserDirectory,
+	catalogRepo repository.CatalogRepository,
+	policy *AccessPolicy,
+	notifiers map[string]notification.Notifier,
+) *ReminderService {
+	return &ReminderService{
+		reminderRepo:  reminderRepo,
+		taskRepo:      taskRepo,
+		userDirectory: userDirectory,
+		catalogRepo:   catalogRepo,
+		policy:        policy,
+		notifiers:     notifiers,
+	}
+}
+
+// CreateReminder - Programa un recordatorio del usuario sobre la tarea
+func (s *ReminderService) CreateReminder(taskID, userID string, reminder *model.Reminder) (*model.Reminder, error) {
+	task, err := s.findTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.Channel == "" {
+		reminder.Channel = DefaultReminderChannel
+	}
+	if _, ok := s.notifiers[reminder.Channel]; !ok {
+		return nil, ErrInvalidChannel
+	}
+
+	now := time.Now().UTC()
+	newReminder := &model.Reminder{
+		ID:            uuid.New().String(),
+		TaskID:        task.ID,
+		UserID:        userID,
+		OffsetMinutes: reminder.OffsetMinutes,
+		RemindAt:      reminder.RemindAt.UTC(),
+		Channel:       reminder.Channel,
+		Status:        model.ReminderPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	switch {
+	case reminder.IsRelative() == !reminder.RemindAt.IsZero():
+		return nil, ErrInvalidReminder
+	case reminder.IsRelative():
+		if *reminder.OffsetMinutes < 0 {
+			return nil, ErrInvalidReminder
+		}
+		if task.DueDate.IsZero() {
+			return nil, ErrReminderNeedsDueDate
+		}
+		newReminder.Reschedule(task.DueDate, now)
+	}
+
+	if !newReminder.RemindAt.After(now) {
+		return nil, ErrReminderInPast
+	}
+
+	if err := s.reminderRepo.Create(newReminder); err != nil {
+		return nil, err
+	}
+	return newReminder, nil
+}
+
+// GetReminders - Recordatorios del usuario sobre la tarea
+func (s *ReminderService) GetReminders(taskID, userID string) ([]*model.Reminder, error) {
+	if _, err := s.findTask(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	reminders, err := s.reminderRepo.FindByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make([]*model.Reminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.UserID == userID {
+			own = append(own, reminder)
+		}
+	}
+	return own, nil
+}
+
+func (s *ReminderService) DeleteReminder(taskID, reminderID, userID string) error {
+	if _, err := s.findTask(taskID, userID); err != nil {
+		return err
+	}
+
+	reminder, err := s.reminderRepo.FindByID(reminderID)
+	if err != nil || reminder == nil || reminder.TaskID != taskID || reminder.UserID != userID {
+		return ErrReminderNotFound
+	}
+	return s.reminderRepo.Delete(reminderID)
+}
+
+// DispatchDue - Envía los recordatorios vencidos. Lo ejecuta el scheduler periódicamente;
+// cada recordatorio se reclama antes de enviarlo para que no salga dos veces.
+func (s *ReminderService) DispatchDue(ctx context.Context) error {
+	now := time.Now().UTC()
+	due, err := s.reminderRepo.FindDue(now, reminderBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		claimed, err := s.reminderRepo.Claim(reminder.ID, now, now.Add(reminderLease))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		reminder.Attempts++
+
+		if err := s.deliver(ctx, reminder); err != nil {
+			log.Printf("Error guardando recordatorio %s: %v", reminder.ID, err)
+		}
+	}
+	return nil
+}
+
+// ------------------------- HELPERS ------------------------- //
+
+// findTask verifica que el usuario pueda ver la tarea (basta para gestionar sus propios recordatorios)
+func (s *ReminderService) findTask(taskID, userID string) (*model.Task, error) {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil || task == nil {
+		return nil, ErrTaskNotFound
+	}
+	if err := s.policy.AuthorizeTask(task, userID, ActionRead); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// deliver envía un recordatorio ya reclamado y guarda el resultado.
+// Los fallos se reintentan con espera exponencial hasta MaxReminderAttempts.
+func (s *ReminderService) deliver(ctx context.Context, reminder *model.Reminder) error {
+	now := time.Now().UTC()
+	reminder.UpdatedAt = now
+
+	task, err := s.taskRepo.FindByID(reminder.TaskID)
+	if err != nil || task == nil {
+		reminder.Status = model.ReminderCancelled
+		return s.reminderRepo.Update(reminder)
+	}
+	status, err := s.catalogRepo.FindStatusByID(task.StatusID)
+	if err == nil && status.IsDone() {
+		reminder.Status = model.ReminderCancelled
+		return s.reminderRepo.Update(reminder)
+	}
+
+	sendErr := s.send(ctx, reminder, task)
+	if sendErr == nil {
+		reminder.Status = model.ReminderSent
+		reminder.SentAt = &now
+		reminder.LastError = ""
+		return s.reminderRepo.Update(reminder)
+	}
+
+	reminder.LastError = sendErr.Error()
+	if reminder.Attempts >= MaxReminderAttempts {
+		reminder.Status = model.ReminderFailed
+	} else {
+		// Sigue reservado hasta el próximo intento: 1m, 2m, 4m...
+		reminder.LockedUntil = now.Add(time.Minute << (reminder.Attempts - 1))
+	}
+	return s.reminderRepo.Update(reminder)
+}
+
+func (s *ReminderService) send(ctx context.Context, reminder *model.Reminder, task *model.Task) error {
+	notifier, ok := s.notifiers[reminder.Channel]
+	if !ok {
+		return ErrInvalidChannel
+	}
+
+	users, err := s.userDirectory.FindByIDs([]string{reminder.UserID})
+	if err != nil {
+		return err
+	}
+	user, ok := users[reminder.UserID]
+	if !ok {
+		return fmt.Errorf("usuario %s no encontrado", reminder.UserID)
+	}
+
+	body := fmt.Sprintf("Recordatorio de la tarea «%s».", task.Title)
+	data := map[string]string{"taskId": task.ID, "reminderId": reminder.ID}
+	if !task.DueDate.IsZero() {
+		body = fmt.Sprintf("La tarea «%s» vence el %s.", task.Title, task.DueDate.Format(time.RFC3339))
+		data["dueDate"] = task.DueDate.Format(time.RFC3339)
+	}
+
+	return notifier.Send(ctx, notification.Message{
+		ID:      reminder.ID,
+		To:      user.Email,
+		Subject: "Recordatorio: " + task.Title,
+		Body:    body,
+		Data:    data,
+	})
+}
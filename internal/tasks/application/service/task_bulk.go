@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/repository"
+	"time"
+)
+
+// Errores del dominio
+var (
+	ErrInvalidBulkAction = errors.New("operación inválida, usar set_status, set_priority, move, add_label o delete")
+	ErrInvalidBulkSize   = errors.New("indicar entre 1 y 100 tareas")
+	ErrBulkMissingValue  = errors.New("falta el valor de la operación (statusId, priorityId o labelId)")
+	ErrBulkFailed        = errors.New("no se aplicó ningún cambio: la operación falló en al menos una tarea")
+)
+
+const MaxBulkItems = 100
+
+// BulkUpdate - Aplica la operación a todas las tareas en una única transacción, con las
+// mismas comprobaciones que las operaciones individuales. Si alguna tarea falla se
+// deshacen todos los cambios y se retorna ErrBulkFailed junto con el informe por tarea.
+func (s *TaskService) BulkUpdate(op *model.BulkOperation, userID, requestID string) (*model.BulkResult, error) {
+	if !op.Action.IsValid() {
+		return nil, ErrInvalidBulkAction
+	}
+	taskIDs := uniqueIDs(op.TaskIDs)
+	if len(taskIDs) == 0 || len(taskIDs) > MaxBulkItems {
+		return nil, ErrInvalidBulkSize
+	}
+	if (op.Action == model.BulkSetStatus && op.StatusID < 1) ||
+		(op.Action == model.BulkSetPriority && op.PriorityID < 1) ||
+		(op.Action == model.BulkAddLabel && op.LabelID == "") {
+		return nil, ErrBulkMissingValue
+	}
+	if op.Action == model.BulkAddLabel {
+		label, err := s.labelRepo.FindByID(op.LabelID)
+		if err != nil || label == nil || label.UserID != userID {
+			return nil, ErrLabelNotFound
+		}
+	}
+
+	result := &model.BulkResult{Action: op.Action, Results: make([]model.BulkItemResult, 0, len(taskIDs))}
+	startedAt := time.Now()
+
+	err := s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+		tx := s.withRepositories(repos)
+
+		failed := false
+		for _, taskID := range taskIDs {
+			item := model.BulkItemResult{TaskID: taskID, Success: true}
+			if err := tx.applyBulk(op, taskID, userID, requestID, startedAt); err != nil {
+				item.Success = false
+				item.Error = err.Error()
+				failed = true
+			}
+			result.Results = append(result.Results, item)
+		}
+
+		if failed {
+			return ErrBulkFailed
+		}
+		return nil
+	})
+	if err == ErrBulkFailed {
+		return result, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Applied = true
+	return result, nil
+}
+
+// --------------------- Helpers ---------------------
+
+// applyBulk aplica la operación a una tarea reutilizando las operaciones individuales
+func (s *TaskService) applyBulk(op *model.BulkOperation, taskID, userID, requestID string, startedAt time.Time) error {
+	var err error
+	switch op.Action {
+	case model.BulkSetStatus:
+		_, err = s.ChangeStatus(taskID, userID, op.StatusID, 0, false, requestID)
+	case model.BulkSetPriority:
+		_, err = s.ChangePriority(taskID, userID, op.PriorityID, 0, requestID)
+	case model.BulkMove:
+		_, err = s.MoveTask(taskID, op.ProjectID, userID, requestID)
+	case model.BulkAddLabel:
+		if _, err = s.findAuthorizedTask(taskID, userID, ActionWrite); err == nil {
+			err = s.labelRepo.AssignToTask(taskID, []string{op.LabelID})
+		}
+	case model.BulkDelete:
+		err = s.DeleteTask(taskID, userID, 0, requestID)
+		if err == ErrTaskNotFound && s.deletedSince(taskID, startedAt) {
+			// Ya se envió a la papelera en esta misma operación junto con su tarea padre
+			err = nil
+		}
+	}
+	return err
+}
+
+// deletedSince indica si la tarea entró en la papelera a partir de since
+func (s *TaskService) deletedSince(taskID string, since time.Time) bool {
+	task, err := s.taskRepo.FindDeletedByID(taskID)
+	return err == nil && task != nil && !task.DeletedAt.Before(since)
+}
+
+// withRepositories - Copia del servicio que trabaja con los repositorios de una transacción
+func (s *TaskService) withRepositories(repos repository.TaskRepositories) *TaskService {
+	return &TaskService{
+		taskRepo:       repos.Tasks,
+		labelRepo:      repos.Labels,
+		projectRepo:    repos.Projects,
+		seriesRepo:     repos.Series,
+		reminderRepo:   repos.Reminders,
+		catalogRepo:    repos.Catalog,
+		eventRepo:      repos.Events,
+		dependencyRepo: repos.Dependencies,
+		policy:         NewAccessPolicy(repos.Projects, repos.Members),
+		workflow:       s.workflow,
+		transactor:     txTransactor{repos: repos},
+	}
+}
+
+// txTransactor - Transactor de un servicio que ya trabaja dentro de una transacción: las
+// operaciones que necesitan la suya se ejecutan en la misma, con los mismos repositorios
+type txTransactor struct {
+	repos repository.TaskRepositories
+}
+
+func (t txTransactor) WithinTransaction(fn func(repos repository.TaskRepositories) error) error {
+	return fn(t.repos)
+}
+
+// uniqueIDs descarta IDs vacíos y repetidos conservando el orden
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
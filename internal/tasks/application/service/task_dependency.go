@@ -0,0 +1,189 @@
+package service
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+	"time"
+)
+
+// AddDependency - blockerID pasa a bloquear a taskID. Requiere permiso de escritura en
+// ambas tareas y rechaza los enlaces que formarían un ciclo.
+func (s *TaskService) AddDependency(taskID, blockerID, userID string) (*model.TaskDependency, error) {
+	if taskID == blockerID {
+		return nil, ErrDependencyCycle
+	}
+	if _, err := s.findAuthorizedTask(taskID, userID, ActionWrite); err != nil {
+		return nil, err
+	}
+	if _, err := s.findAuthorizedTask(blockerID, userID, ActionWrite); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.dependencyRepo.Find(blockerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDependencyExists
+	}
+
+	// Hay ciclo si la tarea bloqueada ya bloquea (directa o indirectamente) a la bloqueadora
+	reachable, err := s.reachesTask(taskID, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	if reachable {
+		return nil, ErrDependencyCycle
+	}
+
+	dependency := &model.TaskDependency{
+		BlockerID: blockerID,
+		BlockedID: taskID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.dependencyRepo.Create(dependency); err != nil {
+		return nil, err
+	}
+	return dependency, nil
+}
+
+// RemoveDependency - Elimina el enlace entre las dos tareas, en el sentido en que exista.
+// Igual que al crearlo, requiere permiso de escritura en ambas.
+func (s *TaskService) RemoveDependency(taskID, otherID, userID string) error {
+	if _, err := s.findAuthorizedTask(taskID, userID, ActionWrite); err != nil {
+		return err
+	}
+	if _, err := s.findAuthorizedTask(otherID, userID, ActionWrite); err != nil {
+		return err
+	}
+
+	for _, pair := range [][2]string{{otherID, taskID}, {taskID, otherID}} {
+		dependency, err := s.dependencyRepo.Find(pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+		if dependency != nil {
+			return s.dependencyRepo.Delete(dependency.BlockerID, dependency.BlockedID)
+		}
+	}
+	return ErrDependencyNotFound
+}
+
+// GetGraph - Grafo de dependencias conectado con la tarea (bloqueadoras y bloqueadas,
+// directas e indirectas). Solo incluye las tareas que el usuario puede ver y que no
+// están en la papelera.
+func (s *TaskService) GetGraph(taskID, userID string) (*model.TaskGraph, error) {
+	task, err := s.findAuthorizedTask(taskID, userID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := map[string]*model.Task{task.ID: task}
+	order := []*model.Task{task}
+	graph := &model.TaskGraph{Edges: []*model.TaskDependency{}}
+
+	frontier := []string{task.ID}
+	seenEdges := make(map[[2]string]bool)
+	for len(frontier) > 0 {
+		dependencies, err := s.dependencyRepo.FindByTaskIDs(frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		frontier = nil
+		for _, dependency := range dependencies {
+			key := [2]string{dependency.BlockerID, dependency.BlockedID}
+			if seenEdges[key] {
+				continue
+			}
+			seenEdges[key] = true
+
+			for _, id := range key {
+				if _, ok := visible[id]; ok {
+					continue
+				}
+				other, err := s.findAuthorizedTask(id, userID, ActionRead)
+				if err != nil {
+					continue
+				}
+				visible[id] = other
+				order = append(order, other)
+				frontier = append(frontier, id)
+			}
+
+			if visible[dependency.BlockerID] != nil && visible[dependency.BlockedID] != nil {
+				graph.Edges = append(graph.Edges, dependency)
+			}
+		}
+	}
+
+	graph.Nodes = make([]*model.TaskGraphNode, 0, len(order))
+	for _, t := range order {
+		done, err := s.isDone(t.StatusID)
+		if err != nil {
+			return nil, err
+		}
+		graph.Nodes = append(graph.Nodes, &model.TaskGraphNode{
+			ID:       t.ID,
+			Title:    t.Title,
+			StatusID: t.StatusID,
+			Done:     done,
+		})
+	}
+	return graph, nil
+}
+
+// ------------------- Helpers ---------------------
+
+// checkBlockers impide sacar la tarea de PENDING mientras alguna de sus bloqueadoras siga
+// abierta: cualquier otro estado, del sistema o personalizado, cuenta como empezarla o
+// terminarla. Las bloqueadoras en la papelera no cuentan.
+func (s *TaskService) checkBlockers(taskID string, status *model.TaskStatus) error {
+	if status.ID == model.StatusPending {
+		return nil
+	}
+
+	dependencies, err := s.dependencyRepo.FindByBlockedID(taskID)
+	if err != nil {
+		return err
+	}
+	for _, dependency := range dependencies {
+		blocker, err := s.taskRepo.FindByID(dependency.BlockerID)
+		if err != nil || blocker == nil {
+			continue
+		}
+		done, err := s.isDone(blocker.StatusID)
+		if err != nil {
+			return err
+		}
+		if !done {
+			return ErrTaskBlocked
+		}
+	}
+	return nil
+}
+
+// reachesTask indica si desde la tarea from se llega a target siguiendo las dependencias
+// hacia las tareas bloqueadas. Incluye los enlaces de tareas en la papelera, que
+// vuelven a contar si se restauran.
+func (s *TaskService) reachesTask(from, target string) (bool, error) {
+	visited := map[string]bool{from: true}
+	frontier := []string{from}
+	for len(frontier) > 0 {
+		dependencies, err := s.dependencyRepo.FindByBlockerIDs(frontier)
+		if err != nil {
+			return false, err
+		}
+
+		frontier = nil
+		for _, dependency := range dependencies {
+			if dependency.BlockedID == target {
+				return true, nil
+			}
+			if !visited[dependency.BlockedID] {
+				visited[dependency.BlockedID] = true
+				frontier = append(frontier, dependency.BlockedID)
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"errors"
+	"go-task-easy-list/config"
+	"go-task-easy-list/internal/tasks/application/service"
+	"go-task-easy-list/internal/tasks/domain/model"
+	gormRepo "go-task-easy-list/internal/tasks/infrastructure/persistence/gorm"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const testUserID = "u1"
+
+// newTestTaskService crea el servicio sobre una base SQLite temporal con los catálogos
+// del sistema, igual que NewTaskModule
+func newTestTaskService(t *testing.T) (*service.TaskService, *gorm.DB) {
+	t.Helper()
+
+	db, err := config.InitDatabase(filepath.Join(t.TempDir(), "tasks.db") + "?_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatalf("abrir base: %v", err)
+	}
+	workflow, err := model.ParseTaskWorkflow(model.DefaultTaskWorkflow)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectRepo := gormRepo.NewProjectRepository(db)
+	taskService := service.NewTaskService(
+		gormRepo.NewTaskRepository(db),
+		gormRepo.NewLabelRepository(db),
+		projectRepo,
+		gormRepo.NewTaskSeriesRepository(db),
+		gormRepo.NewReminderRepository(db),
+		gormRepo.NewCatalogRepository(db),
+		gormRepo.NewTaskEventRepository(db),
+		gormRepo.NewTaskDependencyRepository(db),
+		service.NewAccessPolicy(projectRepo, gormRepo.NewProjectMemberRepository(db)),
+		workflow,
+		gormRepo.NewTransactor(db),
+	)
+	return taskService, db
+}
+
+// newTestTasks crea una tarea pendiente por cada nombre y retorna sus IDs por nombre
+func newTestTasks(t *testing.T, taskService *service.TaskService, names ...string) map[string]string {
+	t.Helper()
+
+	ids := make(map[string]string, len(names))
+	for _, name := range names {
+		task, err := taskService.CreateTask(&model.Task{
+			Title:      name,
+			StatusID:   model.StatusPending,
+			PriorityID: 1,
+		}, testUserID, "")
+		if err != nil {
+			t.Fatalf("crear tarea %s: %v", name, err)
+		}
+		ids[name] = task.ID
+	}
+	return ids
+}
+
+func TestAddDependency(t *testing.T) {
+	// Cada enlace es {bloqueadora, bloqueada}
+	tests := []struct {
+		name     string
+		existing [][2]string
+		add      [2]string
+		wantErr  error
+	}{
+		{name: "consigo misma", add: [2]string{"a", "a"}, wantErr: service.ErrDependencyCycle},
+		{name: "sin enlaces previos", add: [2]string{"a", "b"}},
+		{
+			name:     "repetida",
+			existing: [][2]string{{"a", "b"}},
+			add:      [2]string{"a", "b"},
+			wantErr:  service.ErrDependencyExists,
+		},
+		{
+			name:     "ciclo directo",
+			existing: [][2]string{{"a", "b"}},
+			add:      [2]string{"b", "a"},
+			wantErr:  service.ErrDependencyCycle,
+		},
+		{
+			name:     "ciclo indirecto",
+			existing: [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			add:      [2]string{"d", "a"},
+			wantErr:  service.ErrDependencyCycle,
+		},
+		{
+			name:     "atajo en la misma dirección",
+			existing: [][2]string{{"a", "b"}, {"b", "c"}},
+			add:      [2]string{"a", "c"},
+		},
+		{
+			name:     "rombo",
+			existing: [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}},
+			add:      [2]string{"c", "d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskService, _ := newTestTaskService(t)
+			ids := newTestTasks(t, taskService, "a", "b", "c", "d")
+
+			for _, link := range tt.existing {
+				if _, err := taskService.AddDependency(ids[link[1]], ids[link[0]], testUserID); err != nil {
+					t.Fatalf("enlace previo %s -> %s: %v", link[0], link[1], err)
+				}
+			}
+
+			_, err := taskService.AddDependency(ids[tt.add[1]], ids[tt.add[0]], testUserID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddDependency %s -> %s: err = %v, se esperaba %v", tt.add[0], tt.add[1], err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChangeStatusWithOpenBlockers(t *testing.T) {
+	taskService, db := newTestTaskService(t)
+	ids := newTestTasks(t, taskService, "bloqueadora", "bloqueada")
+
+	// Un estado abierto personalizado también cuenta como empezar la tarea
+	inReview := &model.TaskStatus{Name: "En revisión", Category: model.StatusCategoryOpen, UserID: testUserID, CreatedAt: time.Now()}
+	if err := gormRepo.NewCatalogRepository(db).CreateStatus(inReview); err != nil {
+		t.Fatalf("crear estado: %v", err)
+	}
+	if _, err := taskService.AddDependency(ids["bloqueada"], ids["bloqueadora"], testUserID); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+
+	for _, statusID := range []int{model.StatusInProgress, inReview.ID} {
+		if _, err := taskService.ChangeStatus(ids["bloqueada"], testUserID, statusID, 0, false, ""); !errors.Is(err, service.ErrTaskBlocked) {
+			t.Fatalf("pasar al estado %d con la bloqueadora abierta: err = %v, se esperaba ErrTaskBlocked", statusID, err)
+		}
+	}
+	if _, err := taskService.ChangeStatus(ids["bloqueada"], testUserID, model.StatusInProgress, 0, true, ""); err != nil {
+		t.Fatalf("empezar con force: %v", err)
+	}
+	if _, err := taskService.ChangeStatus(ids["bloqueada"], testUserID, model.StatusCompleted, 0, false, ""); !errors.Is(err, service.ErrTaskBlocked) {
+		t.Fatalf("terminar con la bloqueadora abierta: err = %v, se esperaba ErrTaskBlocked", err)
+	}
+
+	for _, statusID := range []int{model.StatusInProgress, model.StatusCompleted} {
+		if _, err := taskService.ChangeStatus(ids["bloqueadora"], testUserID, statusID, 0, false, ""); err != nil {
+			t.Fatalf("avanzar la bloqueadora al estado %d: %v", statusID, err)
+		}
+	}
+	if _, err := taskService.ChangeStatus(ids["bloqueada"], testUserID, model.StatusCompleted, 0, false, ""); err != nil {
+		t.Errorf("terminar con la bloqueadora terminada: %v", err)
+	}
+}
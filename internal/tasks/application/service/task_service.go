@@ -2,8 +2,11 @@ package service
 
 import (
 	"errors"
+	"log"
 	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/recurrence"
 	"go-task-easy-list/internal/tasks/domain/repository"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,78 +19,311 @@ var (
 	ErrUnauthorized   = errors.New("no autorizado para esta tarea")
 	ErrInvalidDueDate = errors.New("fecha de vencimiento debe ser en el futuro")
 	ErrInvalidDates   = errors.New("fecha de inicio no puede ser posterior a la fecha de vencimiento")
+	ErrInvalidPagination = errors.New("parámetros de paginación inválidos")
+	ErrEmptySearchQuery  = errors.New("la búsqueda no puede estar vacía")
+	ErrInvalidParent     = errors.New("la tarea padre no existe o no tienes permisos sobre ella")
+	ErrTaskCycle         = errors.New("una tarea no puede ser subtarea de sí misma ni de sus subtareas")
+	ErrInvalidProject    = errors.New("el proyecto no existe o no tienes permisos de escritura en él")
+	ErrRecurrenceNeedsDate    = errors.New("una tarea recurrente necesita fecha de inicio o de vencimiento")
+	ErrInvalidEditScope       = errors.New("scope inválido, usar this o series")
+	ErrRecurrenceScope        = errors.New("para cambiar la recurrencia hay que editar la serie (scope=series)")
+	ErrNotRecurring           = errors.New("la tarea no es recurrente")
+	ErrInvalidOccurrenceCount = errors.New("count debe estar entre 1 y 50")
+	ErrInvalidStatus          = errors.New("estado de tarea inválido")
+	ErrInvalidTransition      = errors.New("el flujo de estados no permite ese cambio")
+	ErrParentInTrash          = errors.New("la tarea padre está en la papelera; restáurala primero")
+	ErrVersionMismatch        = repository.ErrVersionConflict
+	ErrDependencyCycle        = errors.New("la dependencia crearía un ciclo")
+	ErrDependencyExists       = errors.New("la dependencia ya existe")
+	ErrDependencyNotFound     = errors.New("dependencia no encontrada")
+	ErrTaskBlocked            = errors.New("la tarea está bloqueada por tareas sin terminar (usar force para ignorarlo)")
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+
+	DefaultOccurrencePreview = 5
+	MaxOccurrencePreview     = 50
 )
 
 type TaskService struct {
-	taskRepo repository.TaskRepository
+	taskRepo       repository.TaskRepository
+	labelRepo      repository.LabelRepository
+	projectRepo    repository.ProjectRepository
+	seriesRepo     repository.TaskSeriesRepository
+	reminderRepo   repository.ReminderRepository
+	catalogRepo    repository.CatalogRepository
+	eventRepo      repository.TaskEventRepository
+	dependencyRepo repository.TaskDependencyRepository
+	policy         *AccessPolicy
+	workflow       *model.TaskWorkflow
+	transactor     repository.Transactor
 }
 
-func NewTaskService(taskRepo repository.TaskRepository) *TaskService {
-	return &TaskService{taskRepo: taskRepo}
+func NewTaskService(
+	taskRepo repository.TaskRepository,
+	labelRepo repository.LabelRepository,
+	projectRepo repository.ProjectRepository,
+	seriesRepo repository.TaskSeriesRepository,
+	reminderRepo repository.ReminderRepository,
+	catalogRepo repository.CatalogRepository,
+	eventRepo repository.TaskEventRepository,
+	dependencyRepo repository.TaskDependencyRepository,
+	policy *AccessPolicy,
+	workflow *model.TaskWorkflow,
+	transactor repository.Transactor,
+) *TaskService {
+	return &TaskService{
+		taskRepo:       taskRepo,
+		labelRepo:      labelRepo,
+		projectRepo:    projectRepo,
+		seriesRepo:     seriesRepo,
+		reminderRepo:   reminderRepo,
+		catalogRepo:    catalogRepo,
+		eventRepo:      eventRepo,
+		dependencyRepo: dependencyRepo,
+		policy:         policy,
+		workflow:       workflow,
+		transactor:     transactor,
+	}
 }
 
-func (s *TaskService) CreateTask(title, description string, statusID, priorityID int, startsAt, dueDate time.Time, userID string) (*model.Task, error) {
-	if title == "" {
+// CreateTask - Crea la tarea; requestID identifica la petición en el historial
+func (s *TaskService) CreateTask(task *model.Task, userID, requestID string) (*model.Task, error) {
+	if task.Title == "" {
 		return nil, ErrInvalidTitle
 	}
 
-	if !dueDate.IsZero() && dueDate.Before(time.Now()) {
+	if !task.DueDate.IsZero() && task.DueDate.Before(time.Now()) {
 		return nil, ErrInvalidDueDate
 	}
 
-	if !startsAt.IsZero() && !dueDate.IsZero() && startsAt.After(dueDate) {
+	if !task.StartsAt.IsZero() && !task.DueDate.IsZero() && task.StartsAt.After(task.DueDate) {
 		return nil, ErrInvalidDates
 	}
 
+	if task.ParentID != "" {
+		if _, err := s.findOwnedParent(task.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.validateProject(task.ProjectID, userID); err != nil {
+		return nil, err
+	}
+
 	newTask := &model.Task{
 		ID: uuid.New().String(),
 		UserID: userID,
-		Title: title,
-		Description: description,
-		StatusID: statusID,
-		PriorityID: priorityID,
-		StartsAt: startsAt,
-		DueDate: dueDate,
+		ParentID: task.ParentID,
+		ProjectID: task.ProjectID,
+		Title: task.Title,
+		Description: task.Description,
+		StatusID: task.StatusID,
+		PriorityID: task.PriorityID,
+		StartsAt: task.StartsAt,
+		DueDate: task.DueDate,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		RecurrenceRule: task.RecurrenceRule,
 	}
-
-	if err := s.taskRepo.Create(newTask); err != nil {
+	status, err := s.validateCatalog(newTask)
+	if err != nil {
 		return nil, err
 	}
+	if status.IsDone() {
+		newTask.CompletedAt = newTask.CreatedAt
+	}
+
+	// La tarea, su serie y el evento del historial se guardan juntos
+	err = s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+		tx := s.withRepositories(repos)
 
+		if newTask.RecurrenceRule != "" {
+			if err := tx.startSeries(newTask); err != nil {
+				return err
+			}
+		}
+		if err := tx.taskRepo.Create(newTask); err != nil {
+			return err
+		}
+		return tx.recordEvent(model.TaskCreated, nil, newTask, userID, requestID)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return newTask, nil
 }
 
+// CreateSubtask - Crea una tarea hija de parentID.
+// Si no se indica proyecto, la subtarea hereda el de la tarea padre.
+func (s *TaskService) CreateSubtask(parentID string, task *model.Task, userID, requestID string) (*model.Task, error) {
+	parent, err := s.findOwnedParent(parentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.ParentID = parentID
+	if task.ProjectID == "" {
+		task.ProjectID = parent.ProjectID
+	}
+	return s.CreateTask(task, userID, requestID)
+}
+
+// GetSubtasks - Lista las subtareas directas de una tarea del usuario
+func (s *TaskService) GetSubtasks(parentID, userID string) ([]*model.Task, error) {
+	if _, err := s.GetTaskByID(parentID, userID); err != nil {
+		return nil, err
+	}
+
+	subtasks, err := s.taskRepo.FindByParentID(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.enrichTasks(userID, subtasks...); err != nil {
+		return nil, err
+	}
+	return subtasks, nil
+}
+
 func (s *TaskService) GetTasksByUserID(userID string) ([]*model.Task, error) {
 	return s.taskRepo.FindByUserID(userID)
 }
 
-func (s *TaskService) GetTaskByID(id, userID string) (*model.Task, error) {
-	task, err := s.taskRepo.FindByID(id)
+// ListTasks - Lista las tareas del usuario aplicando filtros, orden y paginación
+func (s *TaskService) ListTasks(filter model.TaskFilter) ([]*model.Task, int64, error) {
+	return s.listTasks(filter, filter.UserID)
+}
+
+// listTasks lista las tareas del filtro mostrando (y filtrando por) las etiquetas de viewerID
+func (s *TaskService) listTasks(filter model.TaskFilter, viewerID string) ([]*model.Task, int64, error) {
+	if filter.Limit < 0 || filter.Offset < 0 || filter.Limit > MaxPageLimit {
+		return nil, 0, ErrInvalidPagination
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultPageLimit
+	}
+	filter.ViewerID = viewerID
+	if len(filter.Sort) == 0 {
+		filter.Sort = []model.TaskSort{{Field: model.SortByCreatedAt, Desc: true}}
+	}
+
+	tasks, total, err := s.taskRepo.FindByCriteria(filter)
 	if err != nil {
-		return nil, ErrTaskNotFound
+		return nil, 0, err
 	}
 
-	if task == nil {
-		return nil, ErrTaskNotFound
+	if err := s.enrichTasks(viewerID, tasks...); err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+// ListTasksByCursor - Lista tareas paginando por keyset sobre (created_at, id),
+// estable aunque se inserten tareas mientras el cliente recorre las páginas.
+func (s *TaskService) ListTasksByCursor(filter model.TaskFilter) (*model.TaskPage, error) {
+	if filter.Limit < 0 || filter.Limit > MaxPageLimit {
+		return nil, ErrInvalidPagination
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultPageLimit
+	}
+	limit := filter.Limit
+
+	// Se pide un elemento extra para saber si hay más páginas en esa dirección
+	filter.ViewerID = filter.UserID
+	filter.KeysetMode = true
+	filter.Limit = limit + 1
+	tasks, total, err := s.taskRepo.FindByCriteria(filter)
+	if err != nil {
+		return nil, err
 	}
 
-	if task.UserID != userID {
-		return nil, ErrUnauthorized
+	hasMore := len(tasks) > limit
+	if hasMore {
+		tasks = tasks[:limit]
 	}
 
+	if err := s.enrichTasks(filter.UserID, tasks...); err != nil {
+		return nil, err
+	}
+
+	page := &model.TaskPage{Tasks: tasks, Total: total}
+	if filter.Cursor != nil && filter.Cursor.Backward {
+		// La página anterior se consulta en orden inverso
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+		page.HasPrev = hasMore
+		page.HasNext = true
+	} else {
+		page.HasNext = hasMore
+		page.HasPrev = filter.Cursor != nil
+	}
+
+	return page, nil
+}
+
+// SearchTasks - Búsqueda de texto completo sobre título y descripción, ordenada por relevancia.
+// Admite frases entre comillas ("reunión semanal") y prefijos (docu*).
+func (s *TaskService) SearchTasks(userID, query string, limit, offset int) ([]*model.TaskSearchResult, int64, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, ErrEmptySearchQuery
+	}
+	if limit < 0 || offset < 0 || limit > MaxPageLimit {
+		return nil, 0, ErrInvalidPagination
+	}
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+
+	results, total, err := s.taskRepo.Search(userID, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tasks := make([]*model.Task, 0, len(results))
+	for _, result := range results {
+		tasks = append(tasks, result.Task)
+	}
+	if err := s.enrichTasks(userID, tasks...); err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+func (s *TaskService) GetTaskByID(id, userID string) (*model.Task, error) {
+	task, err := s.findAuthorizedTask(id, userID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.enrichTasks(userID, task); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
-func (s *TaskService) UpdateTask(updatedTask *model.Task, userID string) (*model.Task, error) {
-	existingTask, err := s.taskRepo.FindByID(updatedTask.ID)
-	if err != nil || existingTask == nil {
-		return nil, ErrTaskNotFound
+// UpdateTask - Actualiza la tarea. En tareas recurrentes, scope indica si el cambio
+// afecta solo a esta ocurrencia (this, por defecto) o a la serie (series).
+// Si updatedTask.Version no es 0 debe coincidir con la versión guardada. force permite
+// cambiar el estado aunque la tarea tenga bloqueadoras abiertas.
+func (s *TaskService) UpdateTask(updatedTask *model.Task, userID string, scope model.EditScope, force bool, requestID string) (*model.Task, error) {
+	if scope == "" {
+		scope = model.ScopeThis
+	}
+	if !scope.IsValid() {
+		return nil, ErrInvalidEditScope
 	}
 
-	if existingTask.UserID != userID {
-		return nil, ErrUnauthorized
+	existingTask, err := s.findAuthorizedTask(updatedTask.ID, userID, ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(existingTask, updatedTask.Version); err != nil {
+		return nil, err
 	}
 
 	if updatedTask.Title == "" {
@@ -98,67 +334,1114 @@ func (s *TaskService) UpdateTask(updatedTask *model.Task, userID string) (*model
 		return nil, ErrInvalidDueDate
 	}
 
+	if !updatedTask.StartsAt.IsZero() && !updatedTask.DueDate.IsZero() && updatedTask.StartsAt.After(updatedTask.DueDate) {
+		return nil, ErrInvalidDates
+	}
+
+	if updatedTask.ParentID != "" && updatedTask.ParentID != existingTask.ParentID {
+		if err := s.validateNewParent(existingTask.ID, updatedTask.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if updatedTask.ProjectID != existingTask.ProjectID {
+		if err := s.validateProject(updatedTask.ProjectID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.checkTransition(existingTask.StatusID, updatedTask.StatusID); err != nil {
+		return nil, err
+	}
+
 	taskResponse := &model.Task{
 		ID:        existingTask.ID,
 		UserID:    existingTask.UserID,
+		ParentID:  updatedTask.ParentID,
+		ProjectID: updatedTask.ProjectID,
 		Title:     updatedTask.Title,
 		Description: updatedTask.Description,
 		StatusID:  updatedTask.StatusID,
 		PriorityID: updatedTask.PriorityID,
 		StartsAt:  updatedTask.StartsAt,
 		DueDate:   updatedTask.DueDate,
-		CompletedAt: updatedTask.CompletedAt,
+		CompletedAt: existingTask.CompletedAt,
 		CreatedAt: existingTask.CreatedAt,
 		UpdatedAt: time.Now(),
+		Version:   existingTask.Version,
+		RecurrenceRule: existingTask.RecurrenceRule,
+		SeriesID: existingTask.SeriesID,
+		Occurrence: existingTask.Occurrence,
+	}
+
+	return s.saveEdit(existingTask, taskResponse, updatedTask.RecurrenceRule, scope, force, userID, requestID)
+}
+
+// PatchTask - Actualización parcial (JSON Merge Patch): solo cambian y se validan los
+// campos presentes en patch. scope y force funcionan igual que en UpdateTask.
+func (s *TaskService) PatchTask(taskID string, patch *model.TaskPatch, userID string, scope model.EditScope, force bool, requestID string) (*model.Task, error) {
+	if scope == "" {
+		scope = model.ScopeThis
+	}
+	if !scope.IsValid() {
+		return nil, ErrInvalidEditScope
+	}
+
+	existingTask, err := s.findAuthorizedTask(taskID, userID, ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(existingTask, patch.Version); err != nil {
+		return nil, err
+	}
+
+	task := *existingTask
+	task.UpdatedAt = time.Now()
+	patch.Apply(&task)
+
+	if patch.Title != nil && task.Title == "" {
+		return nil, ErrInvalidTitle
+	}
+
+	if patch.DueDate != nil && !task.DueDate.IsZero() && task.DueDate.Before(existingTask.CreatedAt) {
+		return nil, ErrInvalidDueDate
+	}
+
+	if (patch.StartsAt != nil || patch.DueDate != nil) &&
+		!task.StartsAt.IsZero() && !task.DueDate.IsZero() && task.StartsAt.After(task.DueDate) {
+		return nil, ErrInvalidDates
+	}
+
+	if patch.ParentID != nil && task.ParentID != "" && task.ParentID != existingTask.ParentID {
+		if err := s.validateNewParent(existingTask.ID, task.ParentID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if patch.ProjectID != nil && task.ProjectID != existingTask.ProjectID {
+		if err := s.validateProject(task.ProjectID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if patch.StatusID != nil {
+		if err := s.checkTransition(existingTask.StatusID, task.StatusID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sin recurrenceRule se conserva la regla actual (con scope=series los demás
+	// cambios pasan igualmente a la serie); null termina la serie
+	rule := existingTask.RecurrenceRule
+	if patch.RecurrenceRule != nil {
+		rule = *patch.RecurrenceRule
+		if rule == "" && existingTask.SeriesID != "" && scope == model.ScopeThis {
+			return nil, ErrRecurrenceScope
+		}
+	}
+
+	return s.saveEdit(existingTask, &task, rule, scope, force, userID, requestID)
+}
+
+// saveEdit guarda la edición ya validada de una tarea: comprueba el catálogo, aplica la
+// finalización y la recurrencia, registra el evento y propaga los efectos (recordatorios,
+// subtareas completadas, siguiente ocurrencia)
+func (s *TaskService) saveEdit(existingTask, taskResponse *model.Task, rule string, scope model.EditScope, force bool, userID, requestID string) (*model.Task, error) {
+	status, err := s.validateCatalog(taskResponse)
+	if err != nil {
+		return nil, err
 	}
+	wasDone, err := s.isDone(existingTask.StatusID)
+	if err != nil {
+		return nil, err
+	}
+	completed := status.IsDone() && !wasDone
+	if taskResponse.StatusID != existingTask.StatusID && !force {
+		if err := s.checkBlockers(taskResponse.ID, status); err != nil {
+			return nil, err
+		}
+	}
+	applyCompletion(taskResponse, status.IsDone(), wasDone)
+
+	// La edición, los cambios en la serie, el historial, las subtareas completadas en
+	// cascada y la siguiente ocurrencia se guardan juntos: si algo falla no queda nada
+	// a medias y el cambio se puede reintentar entero
+	err = s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+		tx := s.withRepositories(repos)
+
+		if err := tx.applyRecurrenceChange(existingTask, taskResponse, rule, scope, userID, requestID); err != nil {
+			return err
+		}
+		if err := tx.taskRepo.Update(taskResponse); err != nil {
+			return err
+		}
+		// Se termina la serie después del UPDATE condicionado a la versión: la ocurrencia
+		// editada ya no pertenece a ella y el cambio de versión solo afecta a las demás
+		if existingTask.SeriesID != "" && taskResponse.SeriesID == "" {
+			if err := tx.seriesRepo.Delete(existingTask.SeriesID); err != nil {
+				return err
+			}
+		}
+		if err := tx.recordEvent(model.TaskUpdated, existingTask, taskResponse, userID, requestID); err != nil {
+			return err
+		}
+
+		if !taskResponse.DueDate.Equal(existingTask.DueDate) {
+			if err := tx.rescheduleReminders(taskResponse); err != nil {
+				return err
+			}
+		}
 
-	if err := s.taskRepo.Update(taskResponse); err != nil {
+		if completed {
+			if err := tx.completeDescendants(taskResponse.ID, userID, requestID); err != nil {
+				return err
+			}
+			return tx.spawnNextOccurrence(taskResponse, userID, requestID)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	if err := s.enrichTasks(userID, taskResponse); err != nil {
+		return nil, err
+	}
 	return taskResponse, nil
 }
 
-func (s *TaskService) DeleteTask(id, userID string) error {
-	task, err := s.taskRepo.FindByID(id)
-	if err != nil || task == nil {
-		return ErrTaskNotFound
+// MoveTask - Mueve la tarea (y sus subtareas) a otro proyecto; projectID vacío la deja sin proyecto
+func (s *TaskService) MoveTask(taskID, projectID, userID, requestID string) (*model.Task, error) {
+	task, err := s.findAuthorizedTask(taskID, userID, ActionWrite)
+	if err != nil {
+		return nil, err
 	}
-	if task.UserID != userID {
-		return ErrUnauthorized
+
+	if err := s.validateProject(projectID, userID); err != nil {
+		return nil, err
 	}
 
-	return s.taskRepo.Delete(id)
+	descendants, err := s.collectDescendants(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+		tx := s.withRepositories(repos)
+
+		for _, t := range append([]*model.Task{task}, descendants...) {
+			before := *t
+			t.ProjectID = projectID
+			t.UpdatedAt = now
+			if err := tx.adaptToCatalog(t); err != nil {
+				return err
+			}
+			if err := tx.taskRepo.Update(t); err != nil {
+				return err
+			}
+			if err := tx.recordEvent(model.TaskMoved, &before, t, userID, requestID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.enrichTasks(userID, task); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
-func (s *TaskService) ChangeStatus(taskID, userID string, statusID int) error {
-	task, err := s.taskRepo.FindByID(taskID)
+// ListProjectTasks - Lista las tareas de un proyecto (de cualquier miembro) con los mismos
+// filtros que ListTasks. Requiere acceso de lectura al proyecto.
+func (s *TaskService) ListProjectTasks(projectID, userID string, filter model.TaskFilter) ([]*model.Task, int64, error) {
+	project, err := s.projectRepo.FindByID(projectID)
+	if err != nil || project == nil {
+		return nil, 0, ErrProjectNotFound
+	}
+	if err := s.policy.AuthorizeProject(project, userID, ActionRead); err != nil {
+		return nil, 0, err
+	}
+
+	filter.UserID = ""
+	filter.ProjectID = projectID
+	return s.listTasks(filter, userID)
+}
+
+// DeleteTask - Mueve la tarea y sus subtareas a la papelera. version 0 = sin comprobar.
+func (s *TaskService) DeleteTask(id, userID string, version int, requestID string) error {
+	task, err := s.findAuthorizedTask(id, userID, ActionWrite)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(task, version); err != nil {
+		return err
+	}
+
+	// Al eliminar una tarea se envían también a la papelera todas sus subtareas, con la
+	// misma fecha de borrado para que al restaurarla vuelvan solo las eliminadas con ella
+	deletedAt := time.Now()
+	return s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+		tx := s.withRepositories(repos)
+
+		descendants, err := tx.collectDescendants(id)
+		if err != nil {
+			return err
+		}
+		for i := len(descendants) - 1; i >= 0; i-- {
+			if err := tx.taskRepo.Delete(descendants[i].ID, deletedAt); err != nil {
+				return err
+			}
+			if err := tx.recordEvent(model.TaskDeleted, descendants[i], nil, userID, requestID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.taskRepo.Delete(id, deletedAt); err != nil {
+			return err
+		}
+		return tx.recordEvent(model.TaskDeleted, task, nil, userID, requestID)
+	})
+}
+
+// ChangeStatus - Cambia el estado siguiendo el flujo configurado. Pasar a un estado
+// terminado fija CompletedAt y completa sus subtareas; reabrir la tarea lo limpia.
+// Empezar o terminar una tarea con bloqueadoras abiertas requiere force.
+// version 0 = sin comprobar la versión.
+func (s *TaskService) ChangeStatus(taskID, userID string, statusID, version int, force bool, requestID string) (*model.Task, error) {
+	task, err := s.findAuthorizedTask(taskID, userID, ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(task, version); err != nil {
+		return nil, err
+	}
+
+	if statusID != task.StatusID {
+		if err := s.checkTransition(task.StatusID, statusID); err != nil {
+			return nil, err
+		}
+
+		wasDone, err := s.isDone(task.StatusID)
+		if err != nil {
+			return nil, err
+		}
+		before := *task
+		task.StatusID = statusID
+		task.UpdatedAt = time.Now()
+		status, err := s.validateCatalog(task)
+		if err != nil {
+			return nil, err
+		}
+		if !force {
+			if err := s.checkBlockers(task.ID, status); err != nil {
+				return nil, err
+			}
+		}
+		applyCompletion(task, status.IsDone(), wasDone)
+
+		completed := status.IsDone() && !wasDone
+
+		// La tarea, sus subtareas completadas en cascada y la siguiente ocurrencia de la
+		// serie se guardan juntas: si algo falla el cambio se puede reintentar entero
+		err = s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+			tx := s.withRepositories(repos)
+
+			if err := tx.taskRepo.Update(task); err != nil {
+				return err
+			}
+			if err := tx.recordEvent(model.TaskStatusChanged, &before, task, userID, requestID); err != nil {
+				return err
+			}
+			if completed {
+				if err := tx.completeDescendants(task.ID, userID, requestID); err != nil {
+					return err
+				}
+				return tx.spawnNextOccurrence(task, userID, requestID)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.enrichTasks(userID, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetUpcomingOccurrences - Vista previa de las próximas count ocurrencias de una tarea recurrente
+func (s *TaskService) GetUpcomingOccurrences(taskID, userID string, count int) ([]model.TaskOccurrence, error) {
+	if count == 0 {
+		count = DefaultOccurrencePreview
+	}
+	if count < 1 || count > MaxOccurrencePreview {
+		return nil, ErrInvalidOccurrenceCount
+	}
+
+	task, err := s.findAuthorizedTask(taskID, userID, ActionRead)
+	if err != nil {
+		return nil, err
+	}
+	if task.SeriesID == "" {
+		return nil, ErrNotRecurring
+	}
+
+	series, rule, err := s.loadSeries(task.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Posición de esta ocurrencia dentro de la secuencia que empieza en DTSTART
+	position := task.Occurrence - series.BaseOccurrence + 1
+	if position < 0 {
+		position = 0
+	}
+
+	dates := rule.Occurrences(series.Anchor(), position+count)
+	occurrences := make([]model.TaskOccurrence, 0, count)
+	for i := position; i < len(dates); i++ {
+		startsAt, dueDate := series.DatesAt(dates[i])
+		occurrences = append(occurrences, model.TaskOccurrence{
+			Occurrence: series.BaseOccurrence + i,
+			StartsAt:   startsAt,
+			DueDate:    dueDate,
+		})
+	}
+	return occurrences, nil
+}
+
+func (s *TaskService) ChangePriority(taskID, userID string, priorityID, version int, requestID string) (*model.Task, error) {
+	task, err := s.findAuthorizedTask(taskID, userID, ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(task, version); err != nil {
+		return nil, err
+	}
+
+	if priorityID != task.PriorityID {
+		before := *task
+		task.PriorityID = priorityID
+		task.UpdatedAt = time.Now()
+		if _, err := s.validateCatalog(task); err != nil {
+			return nil, err
+		}
+		err := s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+			tx := s.withRepositories(repos)
+			if err := tx.taskRepo.Update(task); err != nil {
+				return err
+			}
+			return tx.recordEvent(model.TaskPriorityChanged, &before, task, userID, requestID)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.enrichTasks(userID, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ExpandCatalog - Carga en cada tarea su estado y/o prioridad, traducidos a lang
+func (s *TaskService) ExpandCatalog(tasks []*model.Task, expand model.TaskExpand, lang string) error {
+	if len(tasks) == 0 || (!expand.Status && !expand.Priority) {
+		return nil
+	}
+
+	statusIDs := make([]int, 0, len(tasks))
+	priorityIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		statusIDs = append(statusIDs, task.StatusID)
+		priorityIDs = append(priorityIDs, task.PriorityID)
+	}
+
+	if expand.Status {
+		statuses, err := s.catalogRepo.FindStatusesByIDs(statusIDs)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			if status, ok := statuses[task.StatusID]; ok {
+				task.Status = localizeStatus(status, lang)
+			}
+		}
+	}
+
+	if expand.Priority {
+		priorities, err := s.catalogRepo.FindPrioritiesByIDs(priorityIDs)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			if priority, ok := priorities[task.PriorityID]; ok {
+				task.Priority = localizePriority(priority, lang)
+			}
+		}
+	}
+	return nil
+}
+
+// GetTrash - Tareas del usuario en la papelera (sin las subtareas de otra tarea eliminada)
+func (s *TaskService) GetTrash(userID string, limit, offset int) ([]*model.Task, int64, error) {
+	if limit < 0 || offset < 0 || limit > MaxPageLimit {
+		return nil, 0, ErrInvalidPagination
+	}
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+
+	return s.taskRepo.FindTrash(userID, limit, offset)
+}
+
+// RestoreTask - Saca la tarea de la papelera junto con sus subtareas eliminadas.
+// Una subtarea no se puede restaurar mientras su tarea padre siga en la papelera.
+func (s *TaskService) RestoreTask(id, userID, requestID string) (*model.Task, error) {
+	task, err := s.taskRepo.FindDeletedByID(id)
 	if err != nil || task == nil {
-		return ErrTaskNotFound
+		return nil, ErrTaskNotFound
 	}
+	if err := s.policy.AuthorizeTask(task, userID, ActionWrite); err != nil {
+		return nil, err
+	}
+	if task.ParentID != "" {
+		if parent, err := s.taskRepo.FindByID(task.ParentID); err != nil || parent == nil {
+			return nil, ErrParentInTrash
+		}
+	}
+
+	err = s.transactor.WithinTransaction(func(repos repository.TaskRepositories) error {
+		tx := s.withRepositories(repos)
 
-	if task.UserID != userID {
-		return ErrUnauthorized
+		// Solo vuelven las subtareas que se eliminaron junto con la tarea (misma fecha de
+		// borrado); las que ya estaban en la papelera antes siguen en ella
+		restored := []*model.Task{task}
+		visited := map[string]bool{task.ID: true}
+		for i := 0; i < len(restored); i++ {
+			children, err := tx.taskRepo.FindDeletedByParentID(restored[i].ID)
+			if err != nil {
+				return err
+			}
+			for _, child := range children {
+				if !visited[child.ID] && child.DeletedAt.Equal(task.DeletedAt) {
+					visited[child.ID] = true
+					restored = append(restored, child)
+				}
+			}
+		}
+
+		for _, t := range restored {
+			if err := tx.taskRepo.Restore(t.ID); err != nil {
+				return err
+			}
+			t.DeletedAt = time.Time{}
+			if err := tx.recordEvent(model.TaskRestored, nil, t, userID, requestID); err != nil {
+				return err
+			}
+			// Los recordatorios relativos que se cancelaron mientras estaba eliminada vuelven a programarse
+			if err := tx.rescheduleReminders(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	task.StatusID = statusID
-	task.UpdatedAt = time.Now()
+	if err := s.enrichTasks(userID, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// PurgeTrash elimina definitivamente las tareas que llevan en la papelera más de retention.
+// Lo ejecuta un proceso en segundo plano; el historial de las tareas se conserva.
+func (s *TaskService) PurgeTrash(retention time.Duration) error {
+	purged, err := s.taskRepo.PurgeDeleted(time.Now().Add(-retention))
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("[trash] %d tareas eliminadas definitivamente", purged)
+	}
+	return nil
+}
+
+// GetHistory - Historial de cambios de la tarea, del más reciente al más antiguo
+func (s *TaskService) GetHistory(taskID, userID string, limit, offset int) ([]*model.TaskEvent, int64, error) {
+	if limit < 0 || offset < 0 || limit > MaxPageLimit {
+		return nil, 0, ErrInvalidPagination
+	}
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
 
-	return s.taskRepo.Update(task)
+	if _, err := s.findAuthorizedTask(taskID, userID, ActionRead); err != nil {
+		return nil, 0, err
+	}
+	return s.eventRepo.FindByTaskID(taskID, limit, offset)
 }
 
-func (s *TaskService) ChangePriority(taskID, userID string, priorityID int) error {
+// --------------------- Helpers ---------------------
+
+// recordEvent añade al historial el cambio de before a after (nil al crear, restaurar
+// o eliminar). Las ediciones que no cambian ningún campo no se registran.
+func (s *TaskService) recordEvent(action model.TaskAction, before, after *model.Task, userID, requestID string) error {
+	changes := model.DiffTasks(before, after)
+	if len(changes) == 0 && before != nil && after != nil {
+		return nil
+	}
+
+	task := after
+	if task == nil {
+		task = before
+	}
+
+	return s.eventRepo.Create(&model.TaskEvent{
+		ID:        uuid.New().String(),
+		TaskID:    task.ID,
+		ActorID:   userID,
+		Action:    action,
+		Changes:   changes,
+		RequestID: requestID,
+		CreatedAt: time.Now(),
+	})
+}
+
+// findAuthorizedTask obtiene la tarea y verifica con la política de acceso que el usuario pueda realizar la acción
+func (s *TaskService) findAuthorizedTask(taskID, userID string, action Action) (*model.Task, error) {
 	task, err := s.taskRepo.FindByID(taskID)
 	if err != nil || task == nil {
-		return ErrTaskNotFound
+		return nil, ErrTaskNotFound
 	}
 
-	if task.UserID != userID {
-		return ErrUnauthorized
+	if err := s.policy.AuthorizeTask(task, userID, action); err != nil {
+		return nil, err
 	}
+	return task, nil
+}
 
-	task.PriorityID = priorityID
-	task.UpdatedAt = time.Now()
+// validateCatalog verifica que el estado y la prioridad de la tarea estén disponibles
+// en su ámbito (sistema más los del usuario o del proyecto) y retorna el estado
+func (s *TaskService) validateCatalog(task *model.Task) (*model.TaskStatus, error) {
+	scope := model.ScopeOf(task)
+
+	status, err := s.catalogRepo.FindStatusByID(task.StatusID)
+	if err != nil || status == nil || !scope.Contains(status.UserID, status.ProjectID) {
+		return nil, ErrInvalidStatus
+	}
+	priority, err := s.catalogRepo.FindPriorityByID(task.PriorityID)
+	if err != nil || priority == nil || !scope.Contains(priority.UserID, priority.ProjectID) {
+		return nil, ErrInvalidPriority
+	}
+	return status, nil
+}
+
+// adaptToCatalog pasa al equivalente del sistema el estado o la prioridad que no estén
+// disponibles en el ámbito actual de la tarea (p. ej. al moverla de proyecto)
+func (s *TaskService) adaptToCatalog(task *model.Task) error {
+	scope := model.ScopeOf(task)
+
+	status, err := s.catalogRepo.FindStatusByID(task.StatusID)
+	if err != nil {
+		return err
+	}
+	if !scope.Contains(status.UserID, status.ProjectID) {
+		task.StatusID = model.DefaultStatusFor(status.Category)
+	}
+
+	priority, err := s.catalogRepo.FindPriorityByID(task.PriorityID)
+	if err != nil {
+		return err
+	}
+	if !scope.Contains(priority.UserID, priority.ProjectID) {
+		task.PriorityID = model.PriorityMedium
+	}
+	return nil
+}
+
+// checkTransition comprueba que el flujo configurado permita pasar de from a to
+func (s *TaskService) checkTransition(from, to int) error {
+	if from == to {
+		return nil
+	}
+	fromStatus, err := s.catalogRepo.FindStatusByID(from)
+	if err != nil || fromStatus == nil {
+		return ErrInvalidStatus
+	}
+	toStatus, err := s.catalogRepo.FindStatusByID(to)
+	if err != nil || toStatus == nil {
+		return ErrInvalidStatus
+	}
+	if !s.workflow.CanTransition(fromStatus, toStatus) {
+		return ErrInvalidTransition
+	}
+	return nil
+}
+
+// isDone indica si el estado pertenece a la categoría terminada
+func (s *TaskService) isDone(statusID int) (bool, error) {
+	status, err := s.catalogRepo.FindStatusByID(statusID)
+	if err != nil {
+		return false, err
+	}
+	return status.IsDone(), nil
+}
+
+// applyCompletion fija CompletedAt al terminar la tarea y lo limpia al reabrirla
+func applyCompletion(task *model.Task, done, wasDone bool) {
+	switch {
+	case done && !wasDone:
+		task.CompletedAt = task.UpdatedAt
+	case !done:
+		task.CompletedAt = time.Time{}
+	}
+}
+
+// findOwnedParent obtiene la tarea padre verificando que el usuario pueda escribir en ella
+func (s *TaskService) findOwnedParent(parentID, userID string) (*model.Task, error) {
+	parent, err := s.taskRepo.FindByID(parentID)
+	if err != nil || parent == nil {
+		return nil, ErrInvalidParent
+	}
+	if err := s.policy.AuthorizeTask(parent, userID, ActionWrite); err != nil {
+		if err == ErrForbidden {
+			return nil, err
+		}
+		return nil, ErrInvalidParent
+	}
+	return parent, nil
+}
+
+// validateProject verifica que el usuario pueda crear/mover tareas en el proyecto (si se indica)
+func (s *TaskService) validateProject(projectID, userID string) error {
+	if projectID == "" {
+		return nil
+	}
+
+	project, err := s.projectRepo.FindByID(projectID)
+	if err != nil || project == nil {
+		return ErrInvalidProject
+	}
+	if err := s.policy.AuthorizeProject(project, userID, ActionWrite); err != nil {
+		if err == ErrForbidden {
+			return err
+		}
+		return ErrInvalidProject
+	}
+	return nil
+}
+
+// validateNewParent rechaza padres ajenos y ciclos (la tarea no puede colgar
+// de sí misma ni de una de sus descendientes)
+func (s *TaskService) validateNewParent(taskID, parentID, userID string) error {
+	if parentID == taskID {
+		return ErrTaskCycle
+	}
+
+	parent, err := s.findOwnedParent(parentID, userID)
+	if err != nil {
+		return err
+	}
 
-	return s.taskRepo.Update(task)
-}
\ No newline at end of file
+	visited := map[string]bool{}
+	for ancestor := parent; ancestor.ParentID != ""; {
+		if ancestor.ParentID == taskID {
+			return ErrTaskCycle
+		}
+		if visited[ancestor.ParentID] {
+			return ErrTaskCycle
+		}
+		visited[ancestor.ParentID] = true
+
+		ancestor, err = s.taskRepo.FindByID(ancestor.ParentID)
+		if err != nil || ancestor == nil {
+			return ErrInvalidParent
+		}
+	}
+	return nil
+}
+
+// collectDescendants recorre el árbol de subtareas en anchura
+func (s *TaskService) collectDescendants(taskID string) ([]*model.Task, error) {
+	var descendants []*model.Task
+	visited := map[string]bool{taskID: true}
+
+	queue := []string{taskID}
+	for len(queue) > 0 {
+		children, err := s.taskRepo.FindByParentID(queue[0])
+		if err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			descendants = append(descendants, child)
+			queue = append(queue, child.ID)
+		}
+	}
+	return descendants, nil
+}
+
+// checkVersion comprueba la versión que espera el cliente (If-Match); 0 = sin condición
+func checkVersion(task *model.Task, version int) error {
+	if version != 0 && version != task.Version {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// completeDescendants pasa a COMPLETED todas las subtareas que no estén terminadas
+func (s *TaskService) completeDescendants(taskID, userID, requestID string) error {
+	descendants, err := s.collectDescendants(taskID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, child := range descendants {
+		done, err := s.isDone(child.StatusID)
+		if err != nil {
+			return err
+		}
+		if done {
+			continue
+		}
+		before := *child
+		child.StatusID = model.StatusCompleted
+		child.CompletedAt = now
+		child.UpdatedAt = now
+		if err := s.taskRepo.Update(child); err != nil {
+			return err
+		}
+		if err := s.recordEvent(model.TaskStatusChanged, &before, child, userID, requestID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startSeries valida la regla de la tarea y crea la serie de la que será la primera ocurrencia
+func (s *TaskService) startSeries(task *model.Task) error {
+	rule, err := recurrence.Parse(task.RecurrenceRule)
+	if err != nil {
+		return err
+	}
+	if task.StartsAt.IsZero() && task.DueDate.IsZero() {
+		return ErrRecurrenceNeedsDate
+	}
+
+	series := &model.TaskSeries{
+		ID:             uuid.New().String(),
+		UserID:         task.UserID,
+		ProjectID:      task.ProjectID,
+		Title:          task.Title,
+		Description:    task.Description,
+		PriorityID:     task.PriorityID,
+		RecurrenceRule: rule.String(),
+		StartsAt:       task.StartsAt,
+		DueDate:        task.DueDate,
+		BaseOccurrence: 1,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := s.seriesRepo.Create(series); err != nil {
+		return err
+	}
+
+	task.RecurrenceRule = series.RecurrenceRule
+	task.SeriesID = series.ID
+	task.Occurrence = 1
+	return nil
+}
+
+// applyRecurrenceChange aplica el cambio de regla pedido en una edición:
+//   - tarea no recurrente + regla: empieza una serie
+//   - scope=this: la regla no puede cambiar (vacía o igual la conserva)
+//   - scope=series sin regla: termina la serie (saveEdit elimina la plantilla)
+//   - scope=series: actualiza la plantilla y las ocurrencias pendientes
+func (s *TaskService) applyRecurrenceChange(existing, task *model.Task, rule string, scope model.EditScope, userID, requestID string) error {
+	switch {
+	case existing.SeriesID == "":
+		if rule == "" {
+			return nil
+		}
+		task.RecurrenceRule = rule
+		return s.startSeries(task)
+
+	case scope == model.ScopeThis:
+		if rule == "" {
+			return nil
+		}
+		parsed, err := recurrence.Parse(rule)
+		if err != nil {
+			return err
+		}
+		if parsed.String() != existing.RecurrenceRule {
+			return ErrRecurrenceScope
+		}
+		return nil
+
+	case rule == "":
+		// La serie se elimina en saveEdit una vez guardada la ocurrencia
+		task.RecurrenceRule, task.SeriesID, task.Occurrence = "", "", 0
+		return nil
+
+	default:
+		return s.updateSeries(existing, task, rule, userID, requestID)
+	}
+}
+
+// updateSeries copia los cambios de la ocurrencia a la plantilla de la serie.
+// Si cambian la regla o las fechas, la serie se reinicia desde esta ocurrencia.
+func (s *TaskService) updateSeries(existing, task *model.Task, rule, userID, requestID string) error {
+	parsed, err := recurrence.Parse(rule)
+	if err != nil {
+		return err
+	}
+
+	series, _, err := s.loadSeries(existing.SeriesID)
+	if err != nil {
+		return err
+	}
+
+	normalized := parsed.String()
+	if normalized != series.RecurrenceRule ||
+		!task.StartsAt.Equal(existing.StartsAt) || !task.DueDate.Equal(existing.DueDate) {
+		if task.StartsAt.IsZero() && task.DueDate.IsZero() {
+			return ErrRecurrenceNeedsDate
+		}
+		series.StartsAt = task.StartsAt
+		series.DueDate = task.DueDate
+		series.BaseOccurrence = task.Occurrence
+	}
+
+	series.Title = task.Title
+	series.Description = task.Description
+	series.PriorityID = task.PriorityID
+	series.ProjectID = task.ProjectID
+	series.RecurrenceRule = normalized
+	series.UpdatedAt = time.Now()
+	if err := s.seriesRepo.Update(series); err != nil {
+		return err
+	}
+	task.RecurrenceRule = normalized
+
+	// Las demás ocurrencias pendientes adoptan la nueva plantilla
+	occurrences, err := s.taskRepo.FindBySeriesID(series.ID)
+	if err != nil {
+		return err
+	}
+	for _, other := range occurrences {
+		if other.ID == task.ID {
+			continue
+		}
+		done, err := s.isDone(other.StatusID)
+		if err != nil {
+			return err
+		}
+		if done {
+			continue
+		}
+		before := *other
+		other.Title = series.Title
+		other.Description = series.Description
+		other.PriorityID = series.PriorityID
+		other.ProjectID = series.ProjectID
+		other.RecurrenceRule = series.RecurrenceRule
+		other.UpdatedAt = series.UpdatedAt
+		if err := s.taskRepo.Update(other); err != nil {
+			return err
+		}
+		if err := s.recordEvent(model.TaskUpdated, &before, other, userID, requestID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// spawnNextOccurrence crea la ocurrencia siguiente a la tarea completada, salvo que
+// la serie haya terminado (COUNT/UNTIL) o esa ocurrencia ya exista.
+func (s *TaskService) spawnNextOccurrence(task *model.Task, userID, requestID string) error {
+	if task.SeriesID == "" {
+		return nil
+	}
+
+	series, rule, err := s.loadSeries(task.SeriesID)
+	if err == ErrNotRecurring {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	next := task.Occurrence + 1
+	position := next - series.BaseOccurrence + 1
+	if position < 1 {
+		return nil
+	}
+	dates := rule.Occurrences(series.Anchor(), position)
+	if len(dates) < position {
+		return nil
+	}
+
+	occurrences, err := s.taskRepo.FindBySeriesID(series.ID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range occurrences {
+		if existing.Occurrence == next {
+			return nil
+		}
+	}
+
+	startsAt, dueDate := series.DatesAt(dates[position-1])
+	occurrence := &model.Task{
+		ID:             uuid.New().String(),
+		UserID:         series.UserID,
+		ParentID:       task.ParentID,
+		ProjectID:      series.ProjectID,
+		Title:          series.Title,
+		Description:    series.Description,
+		StatusID:       model.StatusPending,
+		PriorityID:     series.PriorityID,
+		StartsAt:       startsAt,
+		DueDate:        dueDate,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		RecurrenceRule: series.RecurrenceRule,
+		SeriesID:       series.ID,
+		Occurrence:     next,
+	}
+	if err := s.taskRepo.Create(occurrence); err != nil {
+		return err
+	}
+	if err := s.recordEvent(model.TaskCreated, nil, occurrence, userID, requestID); err != nil {
+		return err
+	}
+	if err := s.copyReminders(task, occurrence); err != nil {
+		return err
+	}
+
+	// La nueva ocurrencia conserva las etiquetas de la anterior
+	labelIDs, err := s.labelRepo.FindIDsByTaskID(task.ID)
+	if err != nil {
+		return err
+	}
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	return s.labelRepo.AssignToTask(occurrence.ID, labelIDs)
+}
+
+// rescheduleReminders recalcula los recordatorios relativos tras cambiar el vencimiento
+func (s *TaskService) rescheduleReminders(task *model.Task) error {
+	reminders, err := s.reminderRepo.FindByTaskID(task.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, reminder := range reminders {
+		if !reminder.IsRelative() {
+			continue
+		}
+		reminder.Reschedule(task.DueDate, now)
+		if err := s.reminderRepo.Update(reminder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyReminders programa en la nueva ocurrencia los recordatorios relativos de la anterior
+func (s *TaskService) copyReminders(from, to *model.Task) error {
+	if to.DueDate.IsZero() {
+		return nil
+	}
+
+	reminders, err := s.reminderRepo.FindByTaskID(from.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, reminder := range reminders {
+		if !reminder.IsRelative() {
+			continue
+		}
+		copied := &model.Reminder{
+			ID:            uuid.New().String(),
+			TaskID:        to.ID,
+			UserID:        reminder.UserID,
+			OffsetMinutes: reminder.OffsetMinutes,
+			Channel:       reminder.Channel,
+			Status:        model.ReminderPending,
+			CreatedAt:     now,
+		}
+		copied.Reschedule(to.DueDate, now)
+		if err := s.reminderRepo.Create(copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSeries obtiene la plantilla de la serie y su regla ya interpretada
+func (s *TaskService) loadSeries(seriesID string) (*model.TaskSeries, *recurrence.Rule, error) {
+	series, err := s.seriesRepo.FindByID(seriesID)
+	if err != nil || series == nil {
+		return nil, nil, ErrNotRecurring
+	}
+
+	rule, err := recurrence.Parse(series.RecurrenceRule)
+	if err != nil {
+		return nil, nil, err
+	}
+	return series, rule, nil
+}
+
+// enrichTasks completa los datos calculados de cada tarea: progreso de subtareas y
+// etiquetas asignadas. Solo se muestran las etiquetas de userID; las de los demás
+// miembros del proyecto son privadas.
+func (s *TaskService) enrichTasks(userID string, tasks ...*model.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	progress, err := s.taskRepo.CountProgressByParentIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	labels, err := s.labelRepo.FindByTaskIDs(ids, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if p, ok := progress[task.ID]; ok {
+			task.Progress = &p
+		}
+		task.Labels = labels[task.ID]
+	}
+	return nil
+}
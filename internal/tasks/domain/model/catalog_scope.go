@@ -0,0 +1,28 @@
+package model
+
+// CatalogScope - Ámbito de los estados y prioridades disponibles: los del sistema
+// más los del proyecto (si ProjectID no está vacío) o los del usuario.
+type CatalogScope struct {
+	UserID    string
+	ProjectID string
+}
+
+// ScopeOf - Ámbito del catálogo que aplica a una tarea
+func ScopeOf(task *Task) CatalogScope {
+	if task.ProjectID != "" {
+		return CatalogScope{ProjectID: task.ProjectID}
+	}
+	return CatalogScope{UserID: task.UserID}
+}
+
+// Contains indica si una entrada del catálogo (por su dueño) está disponible en el ámbito
+func (s CatalogScope) Contains(userID, projectID string) bool {
+	switch {
+	case userID == "" && projectID == "":
+		return true
+	case s.ProjectID != "":
+		return projectID == s.ProjectID
+	default:
+		return projectID == "" && userID == s.UserID
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type Project struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"userId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Color       string    `json:"color"`
+	Archived    bool      `json:"archived"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// Role es el rol del usuario que consulta (se calcula al leer)
+	Role ProjectRole `json:"role,omitempty"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type ProjectRole string
+
+const (
+	RoleOwner  ProjectRole = "owner"
+	RoleEditor ProjectRole = "editor"
+	RoleViewer ProjectRole = "viewer"
+)
+
+func (r ProjectRole) IsValid() bool {
+	return r == RoleOwner || r == RoleEditor || r == RoleViewer
+}
+
+type ProjectMember struct {
+	ProjectID string      `json:"projectId"`
+	UserID    string      `json:"userId"`
+	Email     string      `json:"email,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Role      ProjectRole `json:"role"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+type ReminderStatus string
+
+const (
+	ReminderPending   ReminderStatus = "pending" // esperando su hora
+	ReminderSending   ReminderStatus = "sending" // reclamado por el scheduler o pendiente de reintento
+	ReminderSent      ReminderStatus = "sent"
+	ReminderFailed    ReminderStatus = "failed"    // agotó los reintentos
+	ReminderCancelled ReminderStatus = "cancelled" // la tarea ya estaba completada
+)
+
+// Reminder - Aviso programado de una tarea. Puede ser absoluto (RemindAt) o
+// relativo al vencimiento (OffsetMinutes antes de DueDate); en ese caso RemindAt
+// se recalcula cuando cambia el vencimiento.
+type Reminder struct {
+	ID            string         `json:"id"`
+	TaskID        string         `json:"taskId"`
+	UserID        string         `json:"userId"`
+	OffsetMinutes *int           `json:"offsetMinutes,omitempty"`
+	RemindAt      time.Time      `json:"remindAt"`
+	Channel       string         `json:"channel"`
+	Status        ReminderStatus `json:"status"`
+	Attempts      int            `json:"attempts"`
+	LastError     string         `json:"lastError,omitempty"`
+	SentAt        *time.Time     `json:"sentAt,omitempty"`
+	LockedUntil   time.Time      `json:"-"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+// IsRelative indica si el recordatorio depende del vencimiento de la tarea
+func (r *Reminder) IsRelative() bool {
+	return r.OffsetMinutes != nil
+}
+
+// Reschedule recalcula un recordatorio relativo tras cambiar el vencimiento de la tarea.
+// Si la nueva hora es futura vuelve a quedar pendiente aunque ya se hubiera enviado.
+func (r *Reminder) Reschedule(dueDate, now time.Time) {
+	if !r.IsRelative() {
+		return
+	}
+	if dueDate.IsZero() {
+		r.Status = ReminderCancelled
+		return
+	}
+
+	r.RemindAt = dueDate.Add(-time.Duration(*r.OffsetMinutes) * time.Minute).UTC()
+	if r.RemindAt.After(now) {
+		r.Status = ReminderPending
+		r.Attempts = 0
+		r.LastError = ""
+		r.SentAt = nil
+		r.LockedUntil = time.Time{}
+	}
+	r.UpdatedAt = now
+}
@@ -2,16 +2,48 @@ package model
 
 import "time"
 
+// Task - Las fechas opcionales (StartsAt, DueDate, CompletedAt, DeletedAt) usan la fecha
+// cero como "sin fecha": se guardan como NULL y no se incluyen en el JSON.
 type Task struct {
 	ID          string    `json:"id"`
 	UserID      string    `json:"userId"`
+	ParentID    string    `json:"parentId,omitempty"`
+	ProjectID   string    `json:"projectId,omitempty"`
 	Title       string    `json:"title"`
 	Description string    `json:"description,omitempty"`
 	StatusID    int       `json:"statusId"`
 	PriorityID  int       `json:"priorityId"`
-	StartsAt    time.Time `json:"startsAt,omitempty"`
-	DueDate     time.Time `json:"dueDate,omitempty"`
-	CompletedAt time.Time `json:"completedAt,omitempty"`
+	StartsAt    time.Time `json:"startsAt,omitzero"`
+	DueDate     time.Time `json:"dueDate,omitzero"`
+	CompletedAt time.Time `json:"completedAt,omitzero"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	// Version aumenta con cada cambio guardado; se publica como ETag (bloqueo optimista)
+	Version     int       `json:"version"`
+	// DeletedAt solo se informa en las tareas de la papelera
+	DeletedAt   time.Time `json:"deletedAt,omitzero"`
+
+	// Recurrencia: regla RRULE de la serie y número de esta ocurrencia dentro de ella
+	RecurrenceRule string `json:"recurrenceRule,omitempty"`
+	SeriesID       string `json:"seriesId,omitempty"`
+	Occurrence     int    `json:"occurrence,omitempty"`
+
+	// Progress se calcula al leer: subtareas completadas / total (nil si no tiene subtareas)
+	Progress *TaskProgress `json:"progress,omitempty"`
+	Labels   []*Label      `json:"labels,omitempty"`
+
+	// Status y Priority solo se cargan si el cliente los pide (?expand=status,priority)
+	Status   *TaskStatus   `json:"status,omitempty"`
+	Priority *TaskPriority `json:"priority,omitempty"`
+}
+
+type TaskProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// TaskExpand - Relaciones que se incluyen en la respuesta (?expand=status,priority)
+type TaskExpand struct {
+	Status   bool
+	Priority bool
 }
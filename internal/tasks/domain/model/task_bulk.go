@@ -0,0 +1,46 @@
+package model
+
+// BulkAction - Operación que se aplica a varias tareas a la vez
+type BulkAction string
+
+const (
+	BulkSetStatus   BulkAction = "set_status"
+	BulkSetPriority BulkAction = "set_priority"
+	BulkMove        BulkAction = "move"
+	BulkAddLabel    BulkAction = "add_label"
+	BulkDelete      BulkAction = "delete"
+)
+
+func (a BulkAction) IsValid() bool {
+	switch a {
+	case BulkSetStatus, BulkSetPriority, BulkMove, BulkAddLabel, BulkDelete:
+		return true
+	}
+	return false
+}
+
+// BulkOperation - Operación masiva: Action sobre TaskIDs con el valor que corresponda
+// (StatusID, PriorityID, ProjectID vacío = sin proyecto, o LabelID)
+type BulkOperation struct {
+	Action     BulkAction
+	TaskIDs    []string
+	StatusID   int
+	PriorityID int
+	ProjectID  string
+	LabelID    string
+}
+
+// BulkItemResult - Resultado de la operación sobre una tarea
+type BulkItemResult struct {
+	TaskID  string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkResult - Informe de una operación masiva. Es atómica: Applied indica si se
+// guardaron los cambios (todas las tareas correctas) o se deshicieron todos.
+type BulkResult struct {
+	Action  BulkAction       `json:"operation"`
+	Applied bool             `json:"applied"`
+	Results []BulkItemResult `json:"results"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// TaskDependency - BlockerID bloquea a BlockedID: la tarea bloqueada no puede empezar
+// ni completarse mientras la bloqueadora no esté terminada
+type TaskDependency struct {
+	BlockerID string    `json:"from"`
+	BlockedID string    `json:"to"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TaskGraphNode - Tarea dentro del grafo de dependencias
+type TaskGraphNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	StatusID int    `json:"statusId"`
+	Done     bool   `json:"done"`
+}
+
+// TaskGraph - Componente del grafo de dependencias (acíclico) que contiene a una tarea.
+// Cada arista va de la tarea bloqueadora (from) a la bloqueada (to).
+type TaskGraph struct {
+	Nodes []*TaskGraphNode  `json:"nodes"`
+	Edges []*TaskDependency `json:"edges"`
+}
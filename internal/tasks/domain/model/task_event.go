@@ -0,0 +1,88 @@
+package model
+
+import "time"
+
+// TaskAction - Tipo de cambio registrado en el historial de una tarea
+type TaskAction string
+
+const (
+	TaskCreated         TaskAction = "created"
+	TaskUpdated         TaskAction = "updated"
+	TaskStatusChanged   TaskAction = "status_changed"
+	TaskPriorityChanged TaskAction = "priority_changed"
+	TaskMoved           TaskAction = "moved"
+	TaskDeleted         TaskAction = "deleted"
+	TaskRestored        TaskAction = "restored"
+)
+
+// FieldChange - Valor de un campo antes y después del cambio (nil si estaba vacío)
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// TaskEvent - Entrada inmutable del historial de una tarea. Se conserva aunque la
+// tarea se elimine; RequestID permite agrupar los eventos de una misma petición
+// (p. ej. las subtareas completadas en cascada).
+type TaskEvent struct {
+	ID        string                 `json:"id"`
+	TaskID    string                 `json:"taskId"`
+	ActorID   string                 `json:"actorId"`
+	Action    TaskAction             `json:"action"`
+	Changes   map[string]FieldChange `json:"changes"`
+	RequestID string                 `json:"requestId,omitempty"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// DiffTasks compara los campos editables de dos versiones de una tarea usando los
+// nombres del JSON. Con before nil se listan los valores iniciales (creación) y con
+// after nil los últimos (eliminación).
+func DiffTasks(before, after *Task) map[string]FieldChange {
+	if before == nil {
+		before = &Task{}
+	}
+	if after == nil {
+		after = &Task{}
+	}
+
+	changes := make(map[string]FieldChange)
+	diffString(changes, "parentId", before.ParentID, after.ParentID)
+	diffString(changes, "projectId", before.ProjectID, after.ProjectID)
+	diffString(changes, "title", before.Title, after.Title)
+	diffString(changes, "description", before.Description, after.Description)
+	diffInt(changes, "statusId", before.StatusID, after.StatusID)
+	diffInt(changes, "priorityId", before.PriorityID, after.PriorityID)
+	diffTime(changes, "startsAt", before.StartsAt, after.StartsAt)
+	diffTime(changes, "dueDate", before.DueDate, after.DueDate)
+	diffTime(changes, "completedAt", before.CompletedAt, after.CompletedAt)
+	diffString(changes, "recurrenceRule", before.RecurrenceRule, after.RecurrenceRule)
+	return changes
+}
+
+func diffString(changes map[string]FieldChange, field, before, after string) {
+	if before == after {
+		return
+	}
+	changes[field] = FieldChange{Before: valueOrNil(before, before == ""), After: valueOrNil(after, after == "")}
+}
+
+func diffInt(changes map[string]FieldChange, field string, before, after int) {
+	if before == after {
+		return
+	}
+	changes[field] = FieldChange{Before: valueOrNil(before, before == 0), After: valueOrNil(after, after == 0)}
+}
+
+func diffTime(changes map[string]FieldChange, field string, before, after time.Time) {
+	if before.Equal(after) {
+		return
+	}
+	changes[field] = FieldChange{Before: valueOrNil(before, before.IsZero()), After: valueOrNil(after, after.IsZero())}
+}
+
+func valueOrNil(value interface{}, empty bool) interface{} {
+	if empty {
+		return nil
+	}
+	return value
+}
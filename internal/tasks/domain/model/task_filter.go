@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+type TaskSortField string
+
+const (
+	SortByCreatedAt TaskSortField = "createdAt"
+	SortByDueDate   TaskSortField = "dueDate"
+	SortByPriority  TaskSortField = "priority"
+)
+
+type TaskSort struct {
+	Field TaskSortField
+	Desc  bool
+}
+
+// TaskCursor - Posición en el orden estable (created_at DESC, id DESC) usado
+// por la paginación por keyset. Backward indica que se pide la página anterior.
+type TaskCursor struct {
+	CreatedAt time.Time
+	ID        string
+	Backward  bool
+}
+
+// TaskFilter - Criterios de búsqueda para listar tareas.
+// Los campos vacíos o nil no se aplican como filtro.
+type TaskFilter struct {
+	UserID      string
+	ProjectID   string
+	StatusIDs   []int
+	PriorityIDs []int
+	LabelIDs    []string // tareas con al menos una de estas etiquetas
+	ViewerID    string   // usuario que consulta: LabelIDs solo tiene en cuenta sus etiquetas
+	DueFrom     *time.Time
+	DueTo       *time.Time
+	HasDueDate  *bool // true: solo con vencimiento, false: solo sin vencimiento
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	Text        string
+	Sort        []TaskSort
+	Limit       int
+	Offset      int
+	// Si KeysetMode es true se ignoran Sort y Offset y se pagina por Cursor
+	KeysetMode bool
+	Cursor     *TaskCursor
+}
+
+// TaskPage - Resultado de una consulta paginada por keyset
+type TaskPage struct {
+	Tasks   []*Task
+	Total   int64
+	HasNext bool
+	HasPrev bool
+}
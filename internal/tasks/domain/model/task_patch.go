@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// TaskPatch - Cambios parciales de una tarea (JSON Merge Patch, RFC 7396). Un campo nil
+// no cambia; un puntero al valor vacío ("", 0, fecha cero) lo borra.
+type TaskPatch struct {
+	ParentID       *string
+	ProjectID      *string
+	Title          *string
+	Description    *string
+	StatusID       *int
+	PriorityID     *int
+	StartsAt       *time.Time
+	DueDate        *time.Time
+	RecurrenceRule *string
+
+	// Version que espera el cliente (If-Match); 0 = sin comprobar
+	Version int
+}
+
+// Apply copia en la tarea los campos presentes. La regla de recurrencia no se aplica
+// aquí porque depende del alcance de la edición (this/series).
+func (p *TaskPatch) Apply(task *Task) {
+	if p.ParentID != nil {
+		task.ParentID = *p.ParentID
+	}
+	if p.ProjectID != nil {
+		task.ProjectID = *p.ProjectID
+	}
+	if p.Title != nil {
+		task.Title = *p.Title
+	}
+	if p.Description != nil {
+		task.Description = *p.Description
+	}
+	if p.StatusID != nil {
+		task.StatusID = *p.StatusID
+	}
+	if p.PriorityID != nil {
+		task.PriorityID = *p.PriorityID
+	}
+	if p.StartsAt != nil {
+		task.StartsAt = *p.StartsAt
+	}
+	if p.DueDate != nil {
+		task.DueDate = *p.DueDate
+	}
+}
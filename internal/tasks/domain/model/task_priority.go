@@ -2,10 +2,25 @@ package model
 
 import "time"
 
+// IDs de las prioridades sembradas en el catálogo (ver seedTaskCatalogs)
+const (
+	PriorityLow    = 1
+	PriorityMedium = 2
+	PriorityHigh   = 3
+)
+
+// TaskPriority - Prioridad del catálogo; Level ordena de menor a mayor urgencia.
+// Igual que los estados, puede ser del sistema, de un usuario o de un proyecto.
 type TaskPriority struct {
 	ID        int       `json:"id"`
-	Code      string    `json:"code"`
+	Code      string    `json:"code,omitempty"`
 	Name      string    `json:"name"`
 	Level     int       `json:"level"`
+	UserID    string    `json:"userId,omitempty"`
+	ProjectID string    `json:"projectId,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
+
+func (p *TaskPriority) IsSystem() bool {
+	return p.UserID == "" && p.ProjectID == ""
+}
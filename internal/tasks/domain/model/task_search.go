@@ -0,0 +1,10 @@
+package model
+
+// TaskSearchResult - Tarea encontrada por búsqueda de texto completo.
+// Rank es menor cuanto más relevante es el resultado (convención de bm25).
+type TaskSearchResult struct {
+	Task               *Task
+	Rank               float64
+	TitleHighlight     string
+	DescriptionSnippet string
+}
@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// EditScope - Alcance de una edición sobre una tarea recurrente
+type EditScope string
+
+const (
+	ScopeThis   EditScope = "this"   // solo esta ocurrencia
+	ScopeSeries EditScope = "series" // la plantilla de la serie y sus ocurrencias pendientes
+)
+
+func (s EditScope) IsValid() bool {
+	return s == ScopeThis || s == ScopeSeries
+}
+
+// TaskSeries - Plantilla de una tarea recurrente. Cada ocurrencia es una tarea
+// normal con SeriesID; al completarla se genera la siguiente a partir de la plantilla.
+type TaskSeries struct {
+	ID             string
+	UserID         string
+	ProjectID      string
+	Title          string
+	Description    string
+	PriorityID     int
+	RecurrenceRule string
+	// Fechas de la ocurrencia que cae en DTSTART y su número dentro de la serie
+	// (1 salvo que la regla o las fechas se hayan cambiado a mitad de la serie)
+	StartsAt       time.Time
+	DueDate        time.Time
+	BaseOccurrence int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Anchor - Fecha de referencia de la regla: el inicio o, si no hay, el vencimiento
+func (s *TaskSeries) Anchor() time.Time {
+	if !s.StartsAt.IsZero() {
+		return s.StartsAt
+	}
+	return s.DueDate
+}
+
+// DatesAt - Fechas de la ocurrencia que cae en anchor, conservando la duración original
+func (s *TaskSeries) DatesAt(anchor time.Time) (startsAt, dueDate time.Time) {
+	if s.StartsAt.IsZero() {
+		return time.Time{}, anchor
+	}
+	if s.DueDate.IsZero() {
+		return anchor, time.Time{}
+	}
+	return anchor, anchor.Add(s.DueDate.Sub(s.StartsAt))
+}
+
+// TaskOccurrence - Ocurrencia futura calculada (vista previa)
+type TaskOccurrence struct {
+	Occurrence int       `json:"occurrence"`
+	StartsAt   time.Time `json:"startsAt,omitzero"`
+	DueDate    time.Time `json:"dueDate,omitzero"`
+}
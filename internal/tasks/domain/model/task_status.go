@@ -2,10 +2,52 @@ package model
 
 import "time"
 
+// IDs de los estados sembrados en el catálogo (ver seedTaskCatalogs)
+const (
+	StatusPending    = 1
+	StatusInProgress = 2
+	StatusCompleted  = 3
+)
+
+// StatusCategory - Semántica de un estado: abierto o terminado. Los estados
+// personalizados eligen una y con ella se decide qué significa completar una tarea.
+type StatusCategory string
+
+const (
+	StatusCategoryOpen StatusCategory = "open"
+	StatusCategoryDone StatusCategory = "done"
+)
+
+func (c StatusCategory) IsValid() bool {
+	return c == StatusCategoryOpen || c == StatusCategoryDone
+}
+
+// DefaultStatusFor - Estado del sistema equivalente a la categoría
+func DefaultStatusFor(category StatusCategory) int {
+	if category == StatusCategoryDone {
+		return StatusCompleted
+	}
+	return StatusPending
+}
+
+// TaskStatus - Estado del catálogo. Los del sistema no tienen usuario ni proyecto
+// y son los únicos con Code; los personalizados pertenecen a un usuario (tareas
+// sin proyecto) o a un proyecto (sus tareas).
 type TaskStatus struct {
-	ID          int       `json:"id"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
-}
\ No newline at end of file
+	ID          int            `json:"id"`
+	Code        string         `json:"code,omitempty"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Category    StatusCategory `json:"category"`
+	UserID      string         `json:"userId,omitempty"`
+	ProjectID   string         `json:"projectId,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
+
+func (s *TaskStatus) IsDone() bool {
+	return s.Category == StatusCategoryDone
+}
+
+func (s *TaskStatus) IsSystem() bool {
+	return s.UserID == "" && s.ProjectID == ""
+}
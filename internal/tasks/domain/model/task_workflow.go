@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Códigos de los estados del sistema, usados para describir el flujo en la configuración
+var statusCodes = map[string]int{
+	"PENDING":     StatusPending,
+	"IN_PROGRESS": StatusInProgress,
+	"COMPLETED":   StatusCompleted,
+}
+
+// DefaultTaskWorkflow - PENDING→IN_PROGRESS→COMPLETED; se puede pausar una tarea
+// en curso y reabrir una completada
+const DefaultTaskWorkflow = "PENDING>IN_PROGRESS,IN_PROGRESS>PENDING,IN_PROGRESS>COMPLETED,COMPLETED>PENDING,COMPLETED>IN_PROGRESS"
+
+// TaskWorkflow - Grafo de transiciones permitidas entre estados de tarea
+type TaskWorkflow struct {
+	transitions map[int]map[int]bool
+}
+
+// ParseTaskWorkflow interpreta una lista de transiciones "ORIGEN>DESTINO" separadas por coma,
+// p. ej. "PENDING>IN_PROGRESS,IN_PROGRESS>COMPLETED"
+func ParseTaskWorkflow(spec string) (*TaskWorkflow, error) {
+	workflow := &TaskWorkflow{transitions: make(map[int]map[int]bool)}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fromCode, toCode, ok := strings.Cut(part, ">")
+		if !ok {
+			return nil, fmt.Errorf("transición inválida %q, usar ORIGEN>DESTINO", part)
+		}
+		from, ok := statusCodes[strings.ToUpper(strings.TrimSpace(fromCode))]
+		if !ok {
+			return nil, fmt.Errorf("estado desconocido %q", fromCode)
+		}
+		to, ok := statusCodes[strings.ToUpper(strings.TrimSpace(toCode))]
+		if !ok {
+			return nil, fmt.Errorf("estado desconocido %q", toCode)
+		}
+
+		if workflow.transitions[from] == nil {
+			workflow.transitions[from] = make(map[int]bool)
+		}
+		workflow.transitions[from][to] = true
+	}
+
+	if len(workflow.transitions) == 0 {
+		return nil, fmt.Errorf("el flujo de estados no define transiciones")
+	}
+	return workflow, nil
+}
+
+// CanTransition indica si se puede pasar de from a to. Quedarse en el mismo estado
+// siempre se permite. Los estados personalizados ocupan en el grafo el lugar del
+// estado del sistema de su categoría (open → PENDING, done → COMPLETED), así que no
+// sirven de atajo para saltarse el flujo.
+func (w *TaskWorkflow) CanTransition(from, to *TaskStatus) bool {
+	if from.ID == to.ID {
+		return true
+	}
+	fromNode, toNode := workflowNode(from), workflowNode(to)
+	if fromNode == toNode {
+		return true
+	}
+	return w.transitions[fromNode][toNode]
+}
+
+// workflowNode - Estado del sistema que representa a status en el grafo
+func workflowNode(status *TaskStatus) int {
+	if status.IsSystem() {
+		return status.ID
+	}
+	return DefaultStatusFor(status.Category)
+}
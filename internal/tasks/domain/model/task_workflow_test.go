@@ -0,0 +1,66 @@
+package model
+
+import "testing"
+
+func TestParseTaskWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "por defecto", spec: DefaultTaskWorkflow},
+		{name: "minúsculas y espacios", spec: " pending > in_progress , ,in_progress>completed "},
+		{name: "vacío", spec: " , ", wantErr: true},
+		{name: "sin separador", spec: "PENDING-COMPLETED", wantErr: true},
+		{name: "origen desconocido", spec: "REVIEW>COMPLETED", wantErr: true},
+		{name: "destino desconocido", spec: "PENDING>DONE", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTaskWorkflow(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTaskWorkflow(%q): err = %v, wantErr = %v", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	workflow, err := ParseTaskWorkflow("PENDING>IN_PROGRESS,IN_PROGRESS>COMPLETED")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := &TaskStatus{ID: StatusPending, Category: StatusCategoryOpen}
+	inProgress := &TaskStatus{ID: StatusInProgress, Category: StatusCategoryOpen}
+	completed := &TaskStatus{ID: StatusCompleted, Category: StatusCategoryDone}
+	// Estados personalizados: ocupan el lugar de PENDING y COMPLETED
+	review := &TaskStatus{ID: 10, Category: StatusCategoryOpen, UserID: "u1"}
+	blocked := &TaskStatus{ID: 11, Category: StatusCategoryOpen, ProjectID: "p1"}
+	archived := &TaskStatus{ID: 12, Category: StatusCategoryDone, UserID: "u1"}
+
+	tests := []struct {
+		name     string
+		from, to *TaskStatus
+		want     bool
+	}{
+		{name: "mismo estado", from: completed, to: completed, want: true},
+		{name: "transición definida", from: pending, to: inProgress, want: true},
+		{name: "transición no definida", from: completed, to: pending, want: false},
+		{name: "salto del flujo", from: pending, to: completed, want: false},
+		{name: "personalizado abierto como PENDING", from: review, to: inProgress, want: true},
+		{name: "personalizado no salta el flujo", from: review, to: archived, want: false},
+		{name: "entre personalizados de la misma categoría", from: review, to: blocked, want: true},
+		{name: "hacia personalizado terminado", from: inProgress, to: archived, want: true},
+		{name: "desde personalizado terminado", from: archived, to: inProgress, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := workflow.CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%d, %d) = %v, se esperaba %v", tt.from.ID, tt.to.ID, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+// Package recurrence implementa el subconjunto de RRULE (RFC 5545) que usan
+// las tareas recurrentes: FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY,
+// BYMONTH y WKST.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("regla de recurrencia inválida")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxScanDays limita la búsqueda de ocurrencias (reglas que casi nunca coinciden, p. ej. 29 de febrero)
+const maxScanDays = 366 * 100
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum - Elemento de BYDAY: día de la semana con ordinal opcional
+// (1MO = primer lunes, -1FR = último viernes, 0 = todos)
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+}
+
+// Parse interpreta una regla como "FREQ=WEEKLY;BYDAY=MO,WE" (admite el prefijo "RRULE:")
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	if value == "" {
+		return nil, invalid("la regla está vacía")
+	}
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, invalid("parte mal formada %q", part)
+		}
+		if seen[key] {
+			return nil, invalid("%s repetido", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = Frequency(val)
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly && rule.Freq != Yearly {
+				err = invalid("FREQ %q no soportada", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(key, val)
+		case "COUNT":
+			rule.Count, err = parsePositive(key, val)
+		case "UNTIL":
+			rule.Until, err = parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(key, val, 1, 31, true)
+		case "BYMONTH":
+			var months []int
+			months, err = parseIntList(key, val, 1, 12, false)
+			for _, m := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(m))
+			}
+		case "WKST":
+			day, ok := weekdayCodes[val]
+			if !ok {
+				err = invalid("WKST %q inválido", val)
+			}
+			rule.WeekStart = day
+		default:
+			err = invalid("%s no está soportado", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, invalid("FREQ es obligatorio")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, invalid("COUNT y UNTIL no pueden usarse juntos")
+	}
+	for _, wd := range rule.ByDay {
+		if wd.N != 0 && rule.Freq != Monthly && rule.Freq != Yearly {
+			return nil, invalid("BYDAY con ordinal solo se admite con FREQ=MONTHLY o YEARLY")
+		}
+	}
+	if rule.Freq == Weekly && len(rule.ByMonthDay) > 0 {
+		return nil, invalid("BYMONTHDAY no se admite con FREQ=WEEKLY")
+	}
+
+	return rule, nil
+}
+
+// String devuelve la regla en forma canónica (orden fijo de las partes)
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByMonth) > 0 {
+		values := make([]string, 0, len(r.ByMonth))
+		for _, m := range r.ByMonth {
+			values = append(values, strconv.Itoa(int(m)))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(values, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		values := make([]string, 0, len(r.ByMonthDay))
+		for _, d := range r.ByMonthDay {
+			values = append(values, strconv.Itoa(d))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(values, ","))
+	}
+	if len(r.ByDay) > 0 {
+		values := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			code := weekdayCode(wd.Weekday)
+			if wd.N != 0 {
+				code = strconv.Itoa(wd.N) + code
+			}
+			values = append(values, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(values, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCode(r.WeekStart))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences devuelve como máximo limit ocurrencias a partir de dtstart.
+// Como en RFC 5545, dtstart es siempre la primera ocurrencia y cuenta para COUNT;
+// las siguientes conservan su hora del día.
+func (r *Rule) Occurrences(dtstart time.Time, limit int) []time.Time {
+	if limit <= 0 {
+		return nil
+	}
+	if r.Count > 0 && limit > r.Count {
+		limit = r.Count
+	}
+
+	occurrences := []time.Time{dtstart}
+	for i := 1; i <= maxScanDays && len(occurrences) < limit; i++ {
+		day := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day()+i,
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location())
+
+		if !r.Until.IsZero() && day.After(r.Until) {
+			break
+		}
+		if r.matches(day, dtstart) {
+			occurrences = append(occurrences, day)
+		}
+	}
+	return occurrences
+}
+
+// ------------------------- HELPERS ------------------------- //
+
+// matches indica si el día pertenece a la serie que empieza en dtstart
+func (r *Rule) matches(day, dtstart time.Time) bool {
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, day.Month()) {
+		return false
+	}
+
+	switch r.Freq {
+	case Daily:
+		if daysBetween(dtstart, day)%r.Interval != 0 {
+			return false
+		}
+		return r.matchesMonthDay(day) && r.matchesWeekday(day, true)
+
+	case Weekly:
+		weeks := daysBetween(r.weekStart(dtstart), r.weekStart(day)) / 7
+		if weeks%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == dtstart.Weekday()
+		}
+		return r.matchesWeekday(day, true)
+
+	case Monthly:
+		months := (day.Year()-dtstart.Year())*12 + int(day.Month()-dtstart.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			return day.Day() == dtstart.Day()
+		}
+		return r.matchesMonthDay(day) && r.matchesWeekday(day, true)
+
+	case Yearly:
+		if (day.Year()-dtstart.Year())%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			if len(r.ByMonth) == 0 && day.Month() != dtstart.Month() {
+				return false
+			}
+			return day.Day() == dtstart.Day()
+		}
+		// Sin BYMONTH, los ordinales de BYDAY se cuentan dentro del año
+		return r.matchesMonthDay(day) && r.matchesWeekday(day, len(r.ByMonth) > 0)
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := daysIn(day.Year(), day.Month())
+	for _, d := range r.ByMonthDay {
+		if d == day.Day() || (d < 0 && last+d+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday evalúa BYDAY; los ordinales se cuentan dentro del mes o del año
+func (r *Rule) matchesWeekday(day time.Time, withinMonth bool) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	position, total := day.YearDay(), time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	if withinMonth {
+		position, total = day.Day(), daysIn(day.Year(), day.Month())
+	}
+	fromStart := (position-1)/7 + 1
+	fromEnd := -((total-position)/7 + 1)
+
+	for _, wd := range r.ByDay {
+		if wd.Weekday != day.Weekday() {
+			continue
+		}
+		if wd.N == 0 || wd.N == fromStart || wd.N == fromEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// weekStart retrocede hasta el primer día de la semana según WKST
+func (r *Rule) weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(r.WeekStart) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+func weekdayCode(day time.Weekday) string {
+	for code, wd := range weekdayCodes {
+		if wd == day {
+			return code
+		}
+	}
+	return ""
+}
+
+func parsePositive(key, val string) (int, error) {
+	n, err := strconv.Atoi(val)
+	if err != nil || n < 1 {
+		return 0, invalid("%s debe ser un entero positivo", key)
+	}
+	return n, nil
+}
+
+func parseUntil(val string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, val); err == nil {
+			if layout == "20060102" {
+				// Una fecha sin hora incluye el día completo
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, invalid("UNTIL %q inválido (usar AAAAMMDD o AAAAMMDDTHHMMSSZ)", val)
+}
+
+func parseByDay(val string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(val, ",") {
+		if len(item) < 2 {
+			return nil, invalid("BYDAY %q inválido", item)
+		}
+		code, prefix := item[len(item)-2:], item[:len(item)-2]
+
+		day, ok := weekdayCodes[code]
+		if !ok {
+			return nil, invalid("BYDAY %q inválido", item)
+		}
+
+		n := 0
+		if prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, invalid("ordinal de BYDAY %q inválido", item)
+			}
+		}
+		days = append(days, WeekdayNum{Weekday: day, N: n})
+	}
+	return days, nil
+}
+
+func parseIntList(key, val string, min, max int, allowNegative bool) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(val, ",") {
+		n, err := strconv.Atoi(item)
+		abs := n
+		if abs < 0 && allowNegative {
+			abs = -abs
+		}
+		if err != nil || abs < min || abs > max {
+			return nil, invalid("%s %q fuera de rango", key, item)
+		}
+		values = append(values, n)
+	}
+	sort.Ints(values)
+	return values, nil
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRule, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,134 @@
+package recurrence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string // forma canónica; vacío si la regla es inválida
+	}{
+		{name: "mínima", value: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefijo y minúsculas", value: " rrule:freq=weekly;byday=we,mo ", want: "FREQ=WEEKLY;BYDAY=WE,MO"},
+		{name: "orden canónico", value: "BYMONTHDAY=15,-1;INTERVAL=2;FREQ=MONTHLY", want: "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1,15"},
+		{name: "ordinal mensual", value: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", want: "FREQ=MONTHLY;COUNT=3;BYDAY=-1FR"},
+		{name: "UNTIL sin hora", value: "FREQ=DAILY;UNTIL=20260110", want: "FREQ=DAILY;UNTIL=20260110T235959Z"},
+		{name: "WKST", value: "FREQ=WEEKLY;WKST=SU", want: "FREQ=WEEKLY;WKST=SU"},
+		{name: "INTERVAL=1 se omite", value: "FREQ=YEARLY;INTERVAL=1;BYMONTH=3,1", want: "FREQ=YEARLY;BYMONTH=1,3"},
+
+		{name: "vacía", value: "  "},
+		{name: "sin FREQ", value: "INTERVAL=2"},
+		{name: "FREQ no soportada", value: "FREQ=HOURLY"},
+		{name: "parte repetida", value: "FREQ=DAILY;FREQ=WEEKLY"},
+		{name: "parte sin valor", value: "FREQ=DAILY;COUNT"},
+		{name: "parte desconocida", value: "FREQ=DAILY;BYSETPOS=1"},
+		{name: "INTERVAL cero", value: "FREQ=DAILY;INTERVAL=0"},
+		{name: "COUNT y UNTIL", value: "FREQ=DAILY;COUNT=2;UNTIL=20300101"},
+		{name: "UNTIL mal formado", value: "FREQ=DAILY;UNTIL=2030-01-01"},
+		{name: "BYDAY desconocido", value: "FREQ=WEEKLY;BYDAY=XX"},
+		{name: "ordinal semanal", value: "FREQ=WEEKLY;BYDAY=1MO"},
+		{name: "ordinal fuera de rango", value: "FREQ=YEARLY;BYDAY=54MO"},
+		{name: "BYMONTHDAY semanal", value: "FREQ=WEEKLY;BYMONTHDAY=1"},
+		{name: "BYMONTHDAY fuera de rango", value: "FREQ=MONTHLY;BYMONTHDAY=32"},
+		{name: "BYMONTH negativo", value: "FREQ=YEARLY;BYMONTH=-1"},
+		{name: "WKST inválido", value: "FREQ=WEEKLY;WKST=XX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.value)
+			if tt.want == "" {
+				if !errors.Is(err, ErrInvalidRule) {
+					t.Fatalf("Parse(%q): err = %v, se esperaba ErrInvalidRule", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.value, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("Parse(%q).String() = %q, se esperaba %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+	// 5 de enero de 2026 es lunes
+	monday := date(2026, time.January, 5)
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []time.Time
+	}{
+		{
+			name: "diaria cada dos días", rule: "FREQ=DAILY;INTERVAL=2", dtstart: monday, limit: 3,
+			want: []time.Time{monday, date(2026, time.January, 7), date(2026, time.January, 9)},
+		},
+		{
+			name: "semanal lunes y miércoles", rule: "FREQ=WEEKLY;BYDAY=MO,WE", dtstart: monday, limit: 4,
+			want: []time.Time{monday, date(2026, time.January, 7), date(2026, time.January, 12), date(2026, time.January, 14)},
+		},
+		{
+			name: "semanal sin BYDAY repite el día de inicio", rule: "FREQ=WEEKLY;INTERVAL=2", dtstart: monday, limit: 3,
+			want: []time.Time{monday, date(2026, time.January, 19), date(2026, time.February, 2)},
+		},
+		{
+			name: "último viernes del mes", rule: "FREQ=MONTHLY;BYDAY=-1FR", dtstart: monday, limit: 3,
+			want: []time.Time{monday, date(2026, time.January, 30), date(2026, time.February, 27)},
+		},
+		{
+			name: "último día del mes", rule: "FREQ=MONTHLY;BYMONTHDAY=-1", dtstart: date(2026, time.January, 31), limit: 3,
+			want: []time.Time{date(2026, time.January, 31), date(2026, time.February, 28), date(2026, time.March, 31)},
+		},
+		{
+			name: "mensual el día 31 salta los meses cortos", rule: "FREQ=MONTHLY", dtstart: date(2026, time.January, 31), limit: 3,
+			want: []time.Time{date(2026, time.January, 31), date(2026, time.March, 31), date(2026, time.May, 31)},
+		},
+		{
+			name: "anual el 29 de febrero", rule: "FREQ=YEARLY", dtstart: date(2024, time.February, 29), limit: 2,
+			want: []time.Time{date(2024, time.February, 29), date(2028, time.February, 29)},
+		},
+		{
+			name: "COUNT limita y cuenta dtstart", rule: "FREQ=DAILY;COUNT=2", dtstart: monday, limit: 5,
+			want: []time.Time{monday, date(2026, time.January, 6)},
+		},
+		{
+			name: "UNTIL incluye el día completo", rule: "FREQ=DAILY;UNTIL=20260107", dtstart: monday, limit: 10,
+			want: []time.Time{monday, date(2026, time.January, 6), date(2026, time.January, 7)},
+		},
+		{
+			name: "sin límite", rule: "FREQ=DAILY", dtstart: monday, limit: 0,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+
+			got := rule.Occurrences(tt.dtstart, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences = %v, se esperaba %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("ocurrencia %d = %v, se esperaba %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
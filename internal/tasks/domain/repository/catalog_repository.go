@@ -0,0 +1,22 @@
+package repository
+
+import "go-task-easy-list/internal/tasks/domain/model"
+
+// CatalogRepository - Estados y prioridades de tareas (del sistema y personalizados)
+type CatalogRepository interface {
+	FindStatuses(scope model.CatalogScope) ([]*model.TaskStatus, error)
+	FindStatusByID(id int) (*model.TaskStatus, error)
+	FindStatusesByIDs(ids []int) (map[int]*model.TaskStatus, error)
+	CreateStatus(status *model.TaskStatus) error
+	UpdateStatus(status *model.TaskStatus) error
+	// DeleteStatus elimina el estado pasando antes sus tareas a reassignTo
+	DeleteStatus(id, reassignTo int) error
+
+	FindPriorities(scope model.CatalogScope) ([]*model.TaskPriority, error)
+	FindPriorityByID(id int) (*model.TaskPriority, error)
+	FindPrioritiesByIDs(ids []int) (map[int]*model.TaskPriority, error)
+	CreatePriority(priority *model.TaskPriority) error
+	UpdatePriority(priority *model.TaskPriority) error
+	// DeletePriority elimina la prioridad pasando antes sus tareas y series a reassignTo
+	DeletePriority(id, reassignTo int) error
+}
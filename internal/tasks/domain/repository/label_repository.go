@@ -0,0 +1,17 @@
+package repository
+
+import "go-task-easy-list/internal/tasks/domain/model"
+
+type LabelRepository interface {
+	Create(label *model.Label) error
+	FindByID(id string) (*model.Label, error)
+	FindByUserID(userID string) ([]*model.Label, error)
+	FindByUserAndName(userID, name string) (*model.Label, error)
+	Update(label *model.Label) error
+	Delete(id string) error
+
+	AssignToTask(taskID string, labelIDs []string) error
+	RemoveFromTask(taskID, labelID string) error
+	FindByTaskIDs(taskIDs []string, userID string) (map[string][]*model.Label, error)
+	FindIDsByTaskID(taskID string) ([]string, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "go-task-easy-list/internal/tasks/domain/model"
+
+type ProjectMemberRepository interface {
+	Save(member *model.ProjectMember) error
+	FindRole(projectID, userID string) (model.ProjectRole, error)
+	FindRolesByUserID(userID string) (map[string]model.ProjectRole, error)
+	FindByProjectID(projectID string) ([]*model.ProjectMember, error)
+	Remove(projectID, userID string) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "go-task-easy-list/internal/tasks/domain/model"
+
+type ProjectRepository interface {
+	Create(project *model.Project) error
+	FindByID(id string) (*model.Project, error)
+	FindByUserID(userID string, includeArchived bool) ([]*model.Project, error)
+	NextPosition(userID string) (int, error)
+	Update(project *model.Project) error
+	Delete(id string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+	"time"
+)
+
+type ReminderRepository interface {
+	Create(reminder *model.Reminder) error
+	FindByID(id string) (*model.Reminder, error)
+	FindByTaskID(taskID string) ([]*model.Reminder, error)
+	Update(reminder *model.Reminder) error
+	Delete(id string) error
+
+	// FindDue - Recordatorios pendientes cuya hora llegó y envíos con la reserva vencida
+	FindDue(now time.Time, limit int) ([]*model.Reminder, error)
+	// Claim reserva el recordatorio hasta lockedUntil de forma atómica;
+	// retorna false si otro proceso ya lo reclamó
+	Claim(id string, now, lockedUntil time.Time) (bool, error)
+}
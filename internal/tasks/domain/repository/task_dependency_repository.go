@@ -0,0 +1,16 @@
+package repository
+
+import "go-task-easy-list/internal/tasks/domain/model"
+
+type TaskDependencyRepository interface {
+	Create(dependency *model.TaskDependency) error
+	Delete(blockerID, blockedID string) error
+	// Find retorna la dependencia o nil si no existe
+	Find(blockerID, blockedID string) (*model.TaskDependency, error)
+	// FindByBlockerIDs - Dependencias en las que alguna de las tareas es la bloqueadora
+	FindByBlockerIDs(blockerIDs []string) ([]*model.TaskDependency, error)
+	// FindByBlockedID - Bloqueadoras directas de la tarea
+	FindByBlockedID(blockedID string) ([]*model.TaskDependency, error)
+	// FindByTaskIDs - Dependencias en las que participa alguna de las tareas, en cualquier sentido
+	FindByTaskIDs(taskIDs []string) ([]*model.TaskDependency, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "go-task-easy-list/internal/tasks/domain/model"
+
+// TaskEventRepository - Historial de cambios de tareas; solo admite añadir y leer
+type TaskEventRepository interface {
+	Create(event *model.TaskEvent) error
+	// FindByTaskID - Eventos de la tarea del más reciente al más antiguo, con el total
+	FindByTaskID(taskID string, limit, offset int) ([]*model.TaskEvent, int64, error)
+}
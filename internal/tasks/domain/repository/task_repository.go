@@ -1,11 +1,37 @@
 package repository
 
-import "go-task-easy-list/internal/tasks/domain/model"
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"time"
+)
+
+// ErrVersionConflict - La tarea cambió desde que se leyó (su versión ya no coincide)
+var ErrVersionConflict = errors.New("la tarea fue modificada por otra petición; vuelve a cargarla")
 
 type TaskRepository interface {
 	Create(task *model.Task) error
 	FindByUserID(userID string) ([]*model.Task, error)
+	FindByCriteria(filter model.TaskFilter) ([]*model.Task, int64, error)
 	FindByID(id string) (*model.Task, error)
+	FindByParentID(parentID string) ([]*model.Task, error)
+	FindBySeriesID(seriesID string) ([]*model.Task, error)
+	CountProgressByParentIDs(parentIDs []string) (map[string]model.TaskProgress, error)
+	Search(userID, query string, limit, offset int) ([]*model.TaskSearchResult, int64, error)
+	// Update guarda la tarea solo si su versión sigue siendo task.Version y la incrementa;
+	// si otra petición la modificó antes retorna ErrVersionConflict
 	Update(task *model.Task) error
-	Delete(id string) error
-}
\ No newline at end of file
+	// Delete mueve la tarea a la papelera con fecha deletedAt; las consultas anteriores ya
+	// no la devuelven. Las subtareas eliminadas en cascada comparten la fecha de su padre.
+	Delete(id string, deletedAt time.Time) error
+
+	// Papelera
+	FindDeletedByID(id string) (*model.Task, error)
+	FindDeletedByParentID(parentID string) ([]*model.Task, error)
+	// FindTrash - Tareas eliminadas del usuario cuya tarea padre no está también en la
+	// papelera, de la más reciente a la más antigua, con el total
+	FindTrash(userID string, limit, offset int) ([]*model.Task, int64, error)
+	Restore(id string) error
+	// PurgeDeleted elimina definitivamente las tareas que están en la papelera desde antes de before
+	PurgeDeleted(before time.Time) (int64, error)
+}
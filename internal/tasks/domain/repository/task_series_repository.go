@@ -0,0 +1,10 @@
+package repository
+
+import "go-task-easy-list/internal/tasks/domain/model"
+
+type TaskSeriesRepository interface {
+	Create(series *model.TaskSeries) error
+	FindByID(id string) (*model.TaskSeries, error)
+	Update(series *model.TaskSeries) error
+	Delete(id string) error
+}
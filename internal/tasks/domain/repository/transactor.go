@@ -0,0 +1,20 @@
+package repository
+
+// TaskRepositories - Repositorios del módulo que comparten una misma transacción
+type TaskRepositories struct {
+	Tasks        TaskRepository
+	Labels       LabelRepository
+	Projects     ProjectRepository
+	Members      ProjectMemberRepository
+	Series       TaskSeriesRepository
+	Reminders    ReminderRepository
+	Catalog      CatalogRepository
+	Events       TaskEventRepository
+	Dependencies TaskDependencyRepository
+}
+
+// Transactor ejecuta fn dentro de una transacción: si fn retorna un error se
+// deshacen todos los cambios hechos con los repositorios recibidos
+type Transactor interface {
+	WithinTransaction(fn func(repos TaskRepositories) error) error
+}
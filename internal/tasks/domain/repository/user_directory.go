@@ -0,0 +1,14 @@
+package repository
+
+// UserSummary - Datos públicos de un usuario que el módulo tasks necesita conocer
+type UserSummary struct {
+	ID    string
+	Email string
+	Name  string
+}
+
+// UserDirectory - Consulta de usuarios registrados (p. ej. para invitar miembros por email)
+type UserDirectory interface {
+	FindByEmail(email string) (*UserSummary, error)
+	FindByIDs(ids []string) (map[string]*UserSummary, error)
+}
@@ -1,42 +1,164 @@
 package config
 
 import (
+	"context"
 	"go-task-easy-list/internal/shared/infrastructure/middleware"
+	"go-task-easy-list/internal/shared/infrastructure/worker"
+	"go-task-easy-list/internal/shared/notification"
+	"go-task-easy-list/internal/shared/pagination"
 	"go-task-easy-list/internal/tasks/application/service"
+	"go-task-easy-list/internal/tasks/domain/model"
 	"go-task-easy-list/internal/tasks/infrastructure/http/handler"
 	gormRepo "go-task-easy-list/internal/tasks/infrastructure/persistence/gorm"
+	"log"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
 )
 
 type TaskModule struct {
-	Handler *handler.TaskHandler
+	Handler         *handler.TaskHandler
+	LabelHandler    *handler.LabelHandler
+	ProjectHandler  *handler.ProjectHandler
+	ReminderHandler *handler.ReminderHandler
+	CatalogHandler  *handler.CatalogHandler
+
+	// ReminderScheduler envía en segundo plano los recordatorios vencidos
+	ReminderScheduler *worker.Worker
+	// TrashPurger elimina definitivamente las tareas que superan la retención de la papelera
+	TrashPurger *worker.Worker
 }
 
-func NewTaskModule(db *gorm.DB) *TaskModule {
+// trashPurgeInterval - Cada cuánto se revisa la papelera
+const trashPurgeInterval = time.Hour
+
+func NewTaskModule(
+	db *gorm.DB,
+	cursorSecret string,
+	notifiers map[string]notification.Notifier,
+	reminderInterval time.Duration,
+	statusWorkflow string,
+	trashRetention time.Duration,
+) *TaskModule {
 	// Repositories
 	taskRepo := gormRepo.NewTaskRepository(db)
+	labelRepo := gormRepo.NewLabelRepository(db)
+	projectRepo := gormRepo.NewProjectRepository(db)
+	seriesRepo := gormRepo.NewTaskSeriesRepository(db)
+	reminderRepo := gormRepo.NewReminderRepository(db)
+	catalogRepo := gormRepo.NewCatalogRepository(db)
+	eventRepo := gormRepo.NewTaskEventRepository(db)
+	dependencyRepo := gormRepo.NewTaskDependencyRepository(db)
+	transactor := gormRepo.NewTransactor(db)
+	memberRepo := gormRepo.NewProjectMemberRepository(db)
+	userDirectory := gormRepo.NewUserDirectory(db)
+
+	if statusWorkflow == "" {
+		statusWorkflow = model.DefaultTaskWorkflow
+	}
+	workflow, err := model.ParseTaskWorkflow(statusWorkflow)
+	if err != nil {
+		log.Printf("TASK_STATUS_WORKFLOW inválido (%v), se usa el flujo por defecto", err)
+		workflow, _ = model.ParseTaskWorkflow(model.DefaultTaskWorkflow)
+	}
 
 	// Services
-	taskService := service.NewTaskService(taskRepo)
+	accessPolicy := service.NewAccessPolicy(projectRepo, memberRepo)
+	taskService := service.NewTaskService(taskRepo, labelRepo, projectRepo, seriesRepo, reminderRepo, catalogRepo, eventRepo, dependencyRepo, accessPolicy, workflow, transactor)
+	labelService := service.NewLabelService(labelRepo, taskRepo, accessPolicy)
+	projectService := service.NewProjectService(projectRepo, memberRepo, userDirectory, accessPolicy)
+	reminderService := service.NewReminderService(reminderRepo, taskRepo, userDirectory, catalogRepo, accessPolicy, notifiers)
+	catalogService := service.NewCatalogService(catalogRepo, projectRepo, accessPolicy)
 
 	// Handlers
-	taskHandler := handler.NewTaskHandler(taskService)
+	taskHandler := handler.NewTaskHandler(taskService, pagination.NewCursorCodec(cursorSecret))
+	labelHandler := handler.NewLabelHandler(labelService)
+	projectHandler := handler.NewProjectHandler(projectService)
+	reminderHandler := handler.NewReminderHandler(reminderService)
+	catalogHandler := handler.NewCatalogHandler(catalogService)
 
 	return &TaskModule{
-		Handler: taskHandler,
+		Handler:           taskHandler,
+		LabelHandler:      labelHandler,
+		ProjectHandler:    projectHandler,
+		ReminderHandler:   reminderHandler,
+		CatalogHandler:    catalogHandler,
+		ReminderScheduler: worker.New("reminders", reminderInterval, reminderService.DispatchDue),
+		TrashPurger: worker.New("trash", trashPurgeInterval, func(ctx context.Context) error {
+			return taskService.PurgeTrash(trashRetention)
+		}),
 	}
 }
 
 // RegisterRoutes registra las rutas del módulo tasks
-func (m *TaskModule) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+// idempotency repite la respuesta de los POST reintentados con la misma Idempotency-Key
+func (m *TaskModule) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware, idempotency *middleware.Idempotency) {
 	r.Route("/api/tasks", func(r chi.Router) {
 		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
 		r.Post("/", m.Handler.CreateTask)
 		r.Get("/", m.Handler.GetTasks)
+		r.Get("/search", m.Handler.SearchTasks)
+		r.Get("/trash", m.Handler.GetTrash)
+		r.Post("/bulk", m.Handler.BulkUpdate)
 		r.Get("/{id}", m.Handler.GetTask)
 		r.Put("/{id}", m.Handler.UpdateTask)
+		r.Patch("/{id}", m.Handler.PatchTask)
 		r.Delete("/{id}", m.Handler.DeleteTask)
+		r.Post("/{id}/restore", m.Handler.RestoreTask)
+		r.Patch("/{id}/status", m.Handler.ChangeStatus)
+		r.Patch("/{id}/priority", m.Handler.ChangePriority)
+		r.Post("/{id}/subtasks", m.Handler.CreateSubtask)
+		r.Get("/{id}/subtasks", m.Handler.GetSubtasks)
+		r.Get("/{id}/occurrences", m.Handler.GetOccurrences)
+		r.Get("/{id}/history", m.Handler.GetHistory)
+		r.Post("/{id}/dependencies", m.Handler.AddDependency)
+		r.Delete("/{id}/dependencies/{otherId}", m.Handler.RemoveDependency)
+		r.Get("/{id}/graph", m.Handler.GetGraph)
+		r.Post("/{id}/move", m.Handler.MoveTask)
+		r.Post("/{id}/labels", m.LabelHandler.AssignLabels)
+		r.Delete("/{id}/labels/{labelId}", m.LabelHandler.RemoveLabel)
+		r.Post("/{id}/reminders", m.ReminderHandler.CreateReminder)
+		r.Get("/{id}/reminders", m.ReminderHandler.GetReminders)
+		r.Delete("/{id}/reminders/{reminderId}", m.ReminderHandler.DeleteReminder)
+	})
+
+	r.Route("/api/labels", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
+		r.Post("/", m.LabelHandler.CreateLabel)
+		r.Get("/", m.LabelHandler.GetLabels)
+		r.Get("/{id}", m.LabelHandler.GetLabel)
+		r.Put("/{id}", m.LabelHandler.UpdateLabel)
+		r.Delete("/{id}", m.LabelHandler.DeleteLabel)
 	})
-}
\ No newline at end of file
+
+	r.Route("/api/catalog", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
+		r.Get("/statuses", m.CatalogHandler.GetStatuses)
+		r.Post("/statuses", m.CatalogHandler.CreateStatus)
+		r.Put("/statuses/{id}", m.CatalogHandler.UpdateStatus)
+		r.Delete("/statuses/{id}", m.CatalogHandler.DeleteStatus)
+		r.Get("/priorities", m.CatalogHandler.GetPriorities)
+		r.Post("/priorities", m.CatalogHandler.CreatePriority)
+		r.Put("/priorities/{id}", m.CatalogHandler.UpdatePriority)
+		r.Delete("/priorities/{id}", m.CatalogHandler.DeletePriority)
+	})
+
+	r.Route("/api/projects", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
+		r.Post("/", m.ProjectHandler.CreateProject)
+		r.Get("/", m.ProjectHandler.GetProjects)
+		r.Get("/{id}", m.ProjectHandler.GetProject)
+		r.Put("/{id}", m.ProjectHandler.UpdateProject)
+		r.Delete("/{id}", m.ProjectHandler.DeleteProject)
+		r.Get("/{id}/tasks", m.Handler.GetProjectTasks)
+		r.Get("/{id}/members", m.ProjectHandler.GetMembers)
+		r.Post("/{id}/members", m.ProjectHandler.InviteMember)
+		r.Put("/{id}/members/{userId}", m.ProjectHandler.UpdateMemberRole)
+		r.Delete("/{id}/members/{userId}", m.ProjectHandler.RemoveMember)
+	})
+}
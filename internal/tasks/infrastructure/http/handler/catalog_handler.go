@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"encoding/json"
+	sharedContext "go-task-easy-list/internal/shared/context"
+	"go-task-easy-list/internal/shared/i18n"
+	sharedhttp "go-task-easy-list/internal/shared/http"
+	format "go-task-easy-list/internal/shared/http/utils"
+	sharedValidation "go-task-easy-list/internal/shared/validation"
+	"go-task-easy-list/internal/tasks/application/service"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type CatalogHandler struct {
+	catalogService *service.CatalogService
+	validator      *validator.Validate
+}
+
+func NewCatalogHandler(catalogService *service.CatalogService) *CatalogHandler {
+	return &CatalogHandler{
+		catalogService: catalogService,
+		validator:      sharedValidation.NewValidator(),
+	}
+}
+
+type StatusRequest struct {
+	ProjectId   string `json:"projectId"`
+	Name        string `json:"name" validate:"required,max=50"`
+	Description string `json:"description" validate:"max=200"`
+	Category    string `json:"category" validate:"omitempty,oneof=open done"`
+}
+
+type PriorityRequest struct {
+	ProjectId string `json:"projectId"`
+	Name      string `json:"name" validate:"required,max=50"`
+	Level     int    `json:"level" validate:"required,min=1"`
+}
+
+// GetStatuses - GET /api/catalog/statuses (?projectId= para el catálogo de un proyecto).
+// Los estados del sistema se traducen según Accept-Language (es, en).
+func (h *CatalogHandler) GetStatuses(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+	statuses, err := h.catalogService.GetStatuses(userID, r.URL.Query().Get("projectId"), lang)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Language", lang)
+	sharedhttp.SuccessResponse(w, http.StatusOK, statuses)
+}
+
+// CreateStatus - POST /api/catalog/statuses
+func (h *CatalogHandler) CreateStatus(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req StatusRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	status, err := h.catalogService.CreateStatus(&model.TaskStatus{
+		ProjectID:   req.ProjectId,
+		Name:        req.Name,
+		Description: req.Description,
+		Category:    model.StatusCategory(req.Category),
+	}, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, status)
+}
+
+// UpdateStatus - PUT /api/catalog/statuses/{id}
+func (h *CatalogHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, service.ErrStatusNotFound.Error())
+		return
+	}
+
+	var req StatusRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	status, err := h.catalogService.UpdateStatus(id, &model.TaskStatus{
+		Name:        req.Name,
+		Description: req.Description,
+		Category:    model.StatusCategory(req.Category),
+	}, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, status)
+}
+
+// DeleteStatus - DELETE /api/catalog/statuses/{id}?reassignTo=
+func (h *CatalogHandler) DeleteStatus(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, service.ErrStatusNotFound.Error())
+		return
+	}
+	reassignTo, ok := parseReassignTo(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.catalogService.DeleteStatus(id, reassignTo, userID); err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// GetPriorities - GET /api/catalog/priorities (?projectId= para el catálogo de un proyecto).
+// Las prioridades del sistema se traducen según Accept-Language (es, en).
+func (h *CatalogHandler) GetPriorities(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+	priorities, err := h.catalogService.GetPriorities(userID, r.URL.Query().Get("projectId"), lang)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Language", lang)
+	sharedhttp.SuccessResponse(w, http.StatusOK, priorities)
+}
+
+// CreatePriority - POST /api/catalog/priorities
+func (h *CatalogHandler) CreatePriority(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req PriorityRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	priority, err := h.catalogService.CreatePriority(&model.TaskPriority{
+		ProjectID: req.ProjectId,
+		Name:      req.Name,
+		Level:     req.Level,
+	}, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, priority)
+}
+
+// UpdatePriority - PUT /api/catalog/priorities/{id}
+func (h *CatalogHandler) UpdatePriority(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, service.ErrPriorityNotFound.Error())
+		return
+	}
+
+	var req PriorityRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	priority, err := h.catalogService.UpdatePriority(id, &model.TaskPriority{
+		Name:  req.Name,
+		Level: req.Level,
+	}, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, priority)
+}
+
+// DeletePriority - DELETE /api/catalog/priorities/{id}?reassignTo=
+func (h *CatalogHandler) DeletePriority(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, service.ErrPriorityNotFound.Error())
+		return
+	}
+	reassignTo, ok := parseReassignTo(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.catalogService.DeletePriority(id, reassignTo, userID); err != nil {
+		sharedhttp.ErrorResponse(w, catalogErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// ------------------------- HELPERS ------------------------- //
+
+// decode lee y valida el cuerpo; si falla ya respondió con 400
+func (h *CatalogHandler) decode(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return false
+	}
+	return true
+}
+
+// parseReassignTo lee ?reassignTo= (0 si no se indica, para usar el reemplazo por defecto)
+func parseReassignTo(w http.ResponseWriter, r *http.Request) (int, bool) {
+	raw := r.URL.Query().Get("reassignTo")
+	if raw == "" {
+		return 0, true
+	}
+	reassignTo, err := strconv.Atoi(raw)
+	if err != nil || reassignTo < 1 {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "reassignTo inválido")
+		return 0, false
+	}
+	return reassignTo, true
+}
+
+func catalogErrorStatus(err error) int {
+	switch err {
+	case service.ErrStatusNotFound, service.ErrPriorityNotFound, service.ErrProjectNotFound:
+		return http.StatusNotFound
+	case service.ErrForbidden, service.ErrSystemCatalog:
+		return http.StatusForbidden
+	case service.ErrCatalogNameExists, service.ErrCategoryChange:
+		return http.StatusConflict
+	case service.ErrInvalidCatalogName, service.ErrInvalidCategory,
+		service.ErrInvalidLevel, service.ErrInvalidReassign:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
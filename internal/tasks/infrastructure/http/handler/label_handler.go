@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	sharedContext "go-task-easy-list/internal/shared/context"
+	sharedhttp "go-task-easy-list/internal/shared/http"
+	format "go-task-easy-list/internal/shared/http/utils"
+	sharedValidation "go-task-easy-list/internal/shared/validation"
+	"go-task-easy-list/internal/tasks/application/service"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type LabelHandler struct {
+	labelService *service.LabelService
+	validator    *validator.Validate
+}
+
+func NewLabelHandler(labelService *service.LabelService) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+		validator:    sharedValidation.NewValidator(),
+	}
+}
+
+type LabelRequest struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+type AssignLabelsRequest struct {
+	LabelIds []string `json:"labelIds" validate:"required,min=1"`
+}
+
+// CreateLabel - POST /api/labels
+func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req LabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	label, err := h.labelService.CreateLabel(req.Name, req.Color, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, labelErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, label)
+}
+
+// GetLabels - GET /api/labels
+func (h *LabelHandler) GetLabels(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	labels, err := h.labelService.GetLabels(userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener etiquetas")
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, labels)
+}
+
+// GetLabel - GET /api/labels/{id}
+func (h *LabelHandler) GetLabel(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	label, err := h.labelService.GetLabelByID(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, label)
+}
+
+// UpdateLabel - PUT /api/labels/{id}
+func (h *LabelHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req LabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	label, err := h.labelService.UpdateLabel(chi.URLParam(r, "id"), req.Name, req.Color, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, labelErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, label)
+}
+
+// DeleteLabel - DELETE /api/labels/{id}
+func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	if err := h.labelService.DeleteLabel(chi.URLParam(r, "id"), userID); err != nil {
+		sharedhttp.ErrorResponse(w, labelErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// AssignLabels - POST /api/tasks/{id}/labels
+func (h *LabelHandler) AssignLabels(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req AssignLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	labels, err := h.labelService.AssignLabels(chi.URLParam(r, "id"), req.LabelIds, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, labelErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, labels)
+}
+
+// RemoveLabel - DELETE /api/tasks/{id}/labels/{labelId}
+func (h *LabelHandler) RemoveLabel(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	err := h.labelService.RemoveLabel(chi.URLParam(r, "id"), chi.URLParam(r, "labelId"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, labelErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// ------------------------- HELPERS ------------------------- //
+func labelErrorStatus(err error) int {
+	switch err {
+	case service.ErrLabelNotFound, service.ErrTaskNotFound:
+		return http.StatusNotFound
+	case service.ErrUnauthorized, service.ErrForbidden:
+		return http.StatusForbidden
+	case service.ErrLabelExists:
+		return http.StatusConflict
+	case service.ErrInvalidLabelName, service.ErrInvalidColor:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
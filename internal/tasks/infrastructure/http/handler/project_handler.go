@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"encoding/json"
+	sharedContext "go-task-easy-list/internal/shared/context"
+	sharedhttp "go-task-easy-list/internal/shared/http"
+	format "go-task-easy-list/internal/shared/http/utils"
+	sharedValidation "go-task-easy-list/internal/shared/validation"
+	"go-task-easy-list/internal/tasks/application/service"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type ProjectHandler struct {
+	projectService *service.ProjectService
+	validator      *validator.Validate
+}
+
+func NewProjectHandler(projectService *service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+		validator:      sharedValidation.NewValidator(),
+	}
+}
+
+type ProjectRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+	Color       string `json:"color" validate:"omitempty,hexcolor"`
+	Archived    bool   `json:"archived"`
+	Position    int    `json:"position" validate:"min=0"`
+}
+
+// CreateProject - POST /api/projects
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	project, err := h.projectService.CreateProject(&model.Project{
+		Name:        req.Name,
+		Description: req.Description,
+		Color:       req.Color,
+	}, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, project)
+}
+
+// GetProjects - GET /api/projects?archived=true
+func (h *ProjectHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	includeArchived := r.URL.Query().Get("archived") == "true"
+
+	projects, err := h.projectService.GetProjects(userID, includeArchived)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener proyectos")
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, projects)
+}
+
+// GetProject - GET /api/projects/{id}
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	project, err := h.projectService.GetProjectByID(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, project)
+}
+
+// UpdateProject - PUT /api/projects/{id}
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	project, err := h.projectService.UpdateProject(&model.Project{
+		ID:          chi.URLParam(r, "id"),
+		Name:        req.Name,
+		Description: req.Description,
+		Color:       req.Color,
+		Archived:    req.Archived,
+		Position:    req.Position,
+	}, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, project)
+}
+
+// DeleteProject - DELETE /api/projects/{id}
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	if err := h.projectService.DeleteProject(chi.URLParam(r, "id"), userID); err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+type InviteMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+type MemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+// GetMembers - GET /api/projects/{id}/members
+func (h *ProjectHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	members, err := h.projectService.GetMembers(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, members)
+}
+
+// InviteMember - POST /api/projects/{id}/members
+func (h *ProjectHandler) InviteMember(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req InviteMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	member, err := h.projectService.InviteMember(chi.URLParam(r, "id"), req.Email, model.ProjectRole(req.Role), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, member)
+}
+
+// UpdateMemberRole - PUT /api/projects/{id}/members/{userId}
+func (h *ProjectHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req MemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	member, err := h.projectService.UpdateMemberRole(chi.URLParam(r, "id"), chi.URLParam(r, "userId"), model.ProjectRole(req.Role), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, member)
+}
+
+// RemoveMember - DELETE /api/projects/{id}/members/{userId}
+func (h *ProjectHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	if err := h.projectService.RemoveMember(chi.URLParam(r, "id"), chi.URLParam(r, "userId"), userID); err != nil {
+		sharedhttp.ErrorResponse(w, projectErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// ------------------------- HELPERS ------------------------- //
+func projectErrorStatus(err error) int {
+	switch err {
+	case service.ErrProjectNotFound, service.ErrMemberNotFound, service.ErrMemberUserNotFound:
+		return http.StatusNotFound
+	case service.ErrForbidden:
+		return http.StatusForbidden
+	case service.ErrProjectCreatorRole:
+		return http.StatusConflict
+	case service.ErrInvalidProjectName, service.ErrInvalidColor, service.ErrInvalidPosition, service.ErrInvalidRole:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	sharedContext "go-task-easy-list/internal/shared/context"
+	sharedhttp "go-task-easy-list/internal/shared/http"
+	format "go-task-easy-list/internal/shared/http/utils"
+	sharedValidation "go-task-easy-list/internal/shared/validation"
+	"go-task-easy-list/internal/tasks/application/service"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type ReminderHandler struct {
+	reminderService *service.ReminderService
+	validator       *validator.Validate
+}
+
+func NewReminderHandler(reminderService *service.ReminderService) *ReminderHandler {
+	return &ReminderHandler{
+		reminderService: reminderService,
+		validator:       sharedValidation.NewValidator(),
+	}
+}
+
+// ReminderRequest - offsetMinutes (antes del vencimiento) o remindAt (hora absoluta)
+type ReminderRequest struct {
+	OffsetMinutes *int       `json:"offsetMinutes" validate:"omitempty,min=0"`
+	RemindAt      *time.Time `json:"remindAt"`
+	Channel       string     `json:"channel" validate:"omitempty,oneof=log email webhook"`
+}
+
+// CreateReminder - POST /api/tasks/{id}/reminders
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req ReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	reminder := &model.Reminder{
+		OffsetMinutes: req.OffsetMinutes,
+		Channel:       req.Channel,
+	}
+	if req.RemindAt != nil {
+		reminder.RemindAt = *req.RemindAt
+	}
+
+	created, err := h.reminderService.CreateReminder(chi.URLParam(r, "id"), userID, reminder)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, reminderErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, created)
+}
+
+// GetReminders - GET /api/tasks/{id}/reminders
+func (h *ReminderHandler) GetReminders(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	reminders, err := h.reminderService.GetReminders(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, reminderErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, reminders)
+}
+
+// DeleteReminder - DELETE /api/tasks/{id}/reminders/{reminderId}
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	err := h.reminderService.DeleteReminder(chi.URLParam(r, "id"), chi.URLParam(r, "reminderId"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, reminderErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// ------------------------- HELPERS ------------------------- //
+func reminderErrorStatus(err error) int {
+	switch err {
+	case service.ErrReminderNotFound, service.ErrTaskNotFound:
+		return http.StatusNotFound
+	case service.ErrUnauthorized, service.ErrForbidden:
+		return http.StatusForbidden
+	case service.ErrInvalidReminder, service.ErrReminderNeedsDueDate,
+		service.ErrReminderInPast, service.ErrInvalidChannel:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
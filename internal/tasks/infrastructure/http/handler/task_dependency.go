@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	sharedContext "go-task-easy-list/internal/shared/context"
+	sharedhttp "go-task-easy-list/internal/shared/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DependencyRequest - Exactamente uno de los dos: la tarea que bloquea a la de la ruta
+// (blockedBy) o la tarea a la que la de la ruta bloquea (blocks)
+type DependencyRequest struct {
+	BlockedBy string `json:"blockedBy"`
+	Blocks    string `json:"blocks"`
+}
+
+// AddDependency - POST /api/tasks/{id}/dependencies
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	taskID := chi.URLParam(r, "id")
+
+	var req DependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+	if (req.BlockedBy == "") == (req.Blocks == "") {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "Indicar blockedBy o blocks (solo uno)")
+		return
+	}
+
+	blockedID, blockerID := taskID, req.BlockedBy
+	if req.Blocks != "" {
+		blockedID, blockerID = req.Blocks, taskID
+	}
+
+	dependency, err := h.taskService.AddDependency(blockedID, blockerID, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, dependency)
+}
+
+// RemoveDependency - DELETE /api/tasks/{id}/dependencies/{otherId}
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	err := h.taskService.RemoveDependency(chi.URLParam(r, "id"), chi.URLParam(r, "otherId"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// GetGraph - GET /api/tasks/{id}/graph
+func (h *TaskHandler) GetGraph(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	graph, err := h.taskService.GetGraph(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, graph)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	sharedhttp "go-task-easy-list/internal/shared/http"
+	"go-task-easy-list/internal/tasks/application/service"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// taskETag - ETag de la respuesta de una tarea ("3-9f86d081884c7d65"): la versión, que es
+// lo que compara If-Match, y un resumen del cuerpo, que cambia también con lo que no
+// aumenta la versión (etiquetas, progreso de subtareas, dependencias, expand, idioma)
+func taskETag(task *model.Task, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strconv.Quote(strconv.Itoa(task.Version) + "-" + hex.EncodeToString(sum[:8]))
+}
+
+// writeTaskResponse responde con la tarea y su ETag. Si If-None-Match coincide con la
+// representación actual responde 304 sin cuerpo.
+func writeTaskResponse(w http.ResponseWriter, r *http.Request, status int, task *model.Task, data interface{}) {
+	body, err := json.Marshal(sharedhttp.Response{Success: true, Data: data})
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al generar la respuesta")
+		return
+	}
+
+	etag := taskETag(task, body)
+	w.Header().Set("ETag", etag)
+	w.Header().Add("Vary", "Accept-Language")
+	if r.Method == http.MethodGet && matchesIfNoneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
+
+// matchesIfNoneMatch compara If-None-Match con el ETag actual: admite "*" y listas
+// separadas por comas, con comparación débil (se ignora el prefijo W/)
+func matchesIfNoneMatch(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch lee la versión que espera el cliente en If-Match. Sin cabecera o con
+// "*" retorna 0 (sin condición); un valor que no es un ETag de tarea nunca coincide.
+func parseIfMatch(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	// Las versiones solo cambian al modificar la tarea, así que se acepta también el ETag débil
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(value, "W/"))
+	if err != nil {
+		return 0, service.ErrVersionMismatch
+	}
+	// Solo cuenta la versión: el resumen del cuerpo no interviene en el bloqueo optimista
+	unquoted, _, _ = strings.Cut(unquoted, "-")
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, service.ErrVersionMismatch
+	}
+	return version, nil
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/application/service"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTaskETag(t *testing.T) {
+	task := &model.Task{ID: "t1", Version: 3}
+
+	etag := taskETag(task, []byte(`{"title":"a"}`))
+	if etag != taskETag(task, []byte(`{"title":"a"}`)) {
+		t.Error("el mismo cuerpo produce ETags distintos")
+	}
+	if etag == taskETag(task, []byte(`{"title":"b"}`)) {
+		t.Error("un cuerpo distinto con la misma versión produce el mismo ETag")
+	}
+
+	// If-Match solo compara la versión del ETag
+	r := httptest.NewRequest(http.MethodPut, "/api/tasks/t1", nil)
+	r.Header.Set("If-Match", etag)
+	if version, err := parseIfMatch(r); err != nil || version != 3 {
+		t.Errorf("parseIfMatch(%s) = %d, %v; se esperaba 3", etag, version, err)
+	}
+}
+
+func TestMatchesIfNoneMatch(t *testing.T) {
+	const etag = `"3-9f86d081884c7d65"`
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "sin cabecera", header: "", want: false},
+		{name: "igual", header: etag, want: true},
+		{name: "comodín", header: " * ", want: true},
+		{name: "débil", header: "W/" + etag, want: true},
+		{name: "en una lista", header: `"2-0000000000000000", W/` + etag, want: true},
+		{name: "otra versión", header: `"4-9f86d081884c7d65"`, want: false},
+		{name: "otro cuerpo", header: `"3-0000000000000000"`, want: false},
+		{name: "sin comillas", header: "3-9f86d081884c7d65", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesIfNoneMatch(tt.header, etag); got != tt.want {
+				t.Errorf("matchesIfNoneMatch(%q) = %v, se esperaba %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int
+		wantErr bool
+	}{
+		{name: "sin cabecera", header: "", want: 0},
+		{name: "comodín", header: "*", want: 0},
+		{name: "ETag de tarea", header: `"7-9f86d081884c7d65"`, want: 7},
+		{name: "débil", header: `W/"7-9f86d081884c7d65"`, want: 7},
+		{name: "solo la versión", header: `"7"`, want: 7},
+		{name: "sin comillas", header: "7", wantErr: true},
+		{name: "versión cero", header: `"0-9f86d081884c7d65"`, wantErr: true},
+		{name: "no numérico", header: `"abc"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/api/tasks/t1", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+
+			version, err := parseIfMatch(r)
+			if tt.wantErr {
+				if !errors.Is(err, service.ErrVersionMismatch) {
+					t.Errorf("parseIfMatch(%q): err = %v, se esperaba ErrVersionMismatch", tt.header, err)
+				}
+				return
+			}
+			if err != nil || version != tt.want {
+				t.Errorf("parseIfMatch(%q) = %d, %v; se esperaba %d", tt.header, version, err, tt.want)
+			}
+		})
+	}
+}
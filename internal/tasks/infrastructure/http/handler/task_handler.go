@@ -2,10 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	sharedContext "go-task-easy-list/internal/shared/context"
+	"go-task-easy-list/internal/shared/i18n"
 	sharedhttp "go-task-easy-list/internal/shared/http"
 	format "go-task-easy-list/internal/shared/http/utils"
 	sharedValidation "go-task-easy-list/internal/shared/validation"
+	"go-task-easy-list/internal/shared/pagination"
 	"go-task-easy-list/internal/tasks/application/service"
 	"go-task-easy-list/internal/tasks/domain/model"
 	"net/http"
@@ -18,118 +21,268 @@ import (
 type TaskHandler struct {
 	taskService *service.TaskService
 	validator   *validator.Validate
+	cursorCodec *pagination.CursorCodec
 }
 
-func NewTaskHandler(taskService *service.TaskService) *TaskHandler {
+func NewTaskHandler(taskService *service.TaskService, cursorCodec *pagination.CursorCodec) *TaskHandler {
 	return &TaskHandler{
 		taskService: taskService,
 		validator:   sharedValidation.NewValidator(),
+		cursorCodec: cursorCodec,
 	}
 }
 
 type TaskRequest struct {
+	ParentId    string `json:"parentId"`
+	ProjectId   string `json:"projectId"`
 	Title       string `json:"title" validate:"required"`
 	Description string `json:"description"`
-	StatusId    int    `json:"statusId" validate:"required,min=1,max=3"`
-	PriorityId  int    `json:"priorityId" validate:"required,min=1,max=3"`
+	StatusId    int    `json:"statusId" validate:"required,min=1"`
+	PriorityId  int    `json:"priorityId" validate:"required,min=1"`
+	// Fechas opcionales (RFC3339); vacías o null = sin fecha
 	StartsAt    string `json:"startsAt"`
 	DueDate     string `json:"dueDate"`
+	// Regla RRULE (RFC 5545), p. ej. "FREQ=WEEKLY;BYDAY=MO,WE"
+	RecurrenceRule string `json:"recurrenceRule"`
 }
 
 type TaskResponse struct {
 	ID          string `json:"id"`
+	ParentId    string `json:"parentId,omitempty"`
+	ProjectId   string `json:"projectId,omitempty"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	StatusId    int    `json:"statusId"`
 	PriorityId  int    `json:"priorityId"`
-	StartsAt    string `json:"startsAt"`
-	DueDate     string `json:"dueDate"`
+	Version     int    `json:"version"`
+	Progress    *model.TaskProgress `json:"progress,omitempty"`
+	Labels      []*model.Label      `json:"labels"`
+	StartsAt    string `json:"startsAt,omitempty"`
+	DueDate     string `json:"dueDate,omitempty"`
+	RecurrenceRule string `json:"recurrenceRule,omitempty"`
+	SeriesId       string `json:"seriesId,omitempty"`
+	Occurrence     int    `json:"occurrence,omitempty"`
+	CompletedAt    string `json:"completedAt,omitempty"`
 	CreatedAt   string `json:"createdAt"`
 	UpdatedAt   string `json:"updatedAt"`
+	DeletedAt   string `json:"deletedAt,omitempty"`
+	// Solo con ?expand=status,priority
+	Status   *model.TaskStatus   `json:"status,omitempty"`
+	Priority *model.TaskPriority `json:"priority,omitempty"`
+}
+
+type OccurrenceResponse struct {
+	Occurrence int    `json:"occurrence"`
+	StartsAt   string `json:"startsAt,omitempty"`
+	DueDate    string `json:"dueDate,omitempty"`
+}
+
+type SearchHighlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type TaskSearchResponse struct {
+	TaskResponse
+	Rank      float64         `json:"rank"`
+	Highlight SearchHighlight `json:"highlight"`
 }
 
 // CreateTask - POST /api/tasks
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	userID := sharedContext.GetUserID(r.Context())
 
-	var req TaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+	taskData, err := h.decodeTaskRequest(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if err := h.validator.Struct(req); err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+	task, err := h.taskService.CreateTask(taskData, userID, sharedContext.GetRequestID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
 		return
 	}
 
-	startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+	if !h.expandTasks(w, r, task) {
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, toTaskResponse(task))
+}
+
+// GetTasks - GET /api/tasks
+//
+// Filtros soportados (query params):
+//   status, priority        IDs separados por coma (ej. status=1,2)
+//   dueFrom, dueTo          rango de fecha de vencimiento (RFC3339)
+//   createdFrom, createdTo  rango de fecha de creación (RFC3339)
+//   updatedFrom, updatedTo  rango de fecha de actualización (RFC3339)
+//   label                   IDs de etiquetas separados por coma (al menos una)
+//   q                       texto a buscar en título y descripción
+//   sort                    dueDate, priority, createdAt (prefijo "-" para descendente)
+//   limit, offset           paginación
+//
+// Paginación por cursor: "pagination=cursor" para la primera página y luego
+// "cursor=<nextCursor|prevCursor>". No se combina con sort ni offset.
+func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	filter, err := parseTaskFilter(r.URL.Query())
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "StartsAt inválido")
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.UserID = userID
+
+	if isCursorPagination(r.URL.Query()) {
+		h.getTasksByCursor(w, r, filter)
 		return
 	}
 
-	dueDate, err := time.Parse(time.RFC3339, req.DueDate)
+	tasks, total, err := h.taskService.ListTasks(filter)
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "DueDate inválido")
+		if err == service.ErrInvalidPagination {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener tareas")
+		return
+	}
+
+	if !h.expandTasks(w, r, tasks...) {
+		return
+	}
+
+	resp := make([]TaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		resp = append(resp, toTaskResponse(task))
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = service.DefaultPageLimit
+	}
+
+	sharedhttp.PaginatedResponse(w, http.StatusOK, resp, sharedhttp.PageMeta{
+		Total:  total,
+		Limit:  limit,
+		Offset: filter.Offset,
+	})
+}
+
+func (h *TaskHandler) getTasksByCursor(w http.ResponseWriter, r *http.Request, filter model.TaskFilter) {
+	if len(filter.Sort) > 0 || filter.Offset > 0 {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "La paginación por cursor no admite 'sort' ni 'offset'")
 		return
 	}
 
-	task, err := h.taskService.CreateTask(
-		req.Title, 
-		req.Description, 
-		req.StatusId, 
-		req.PriorityId, 
-		startsAt, 
-		dueDate, 
-		userID,
-	)
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		var payload cursorPayload
+		if err := h.cursorCodec.Decode(raw, &payload); err != nil {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Cursor = &model.TaskCursor{
+			CreatedAt: payload.CreatedAt,
+			ID:        payload.ID,
+			Backward:  payload.Backward,
+		}
+	}
+
+	page, err := h.taskService.ListTasksByCursor(filter)
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		if err == service.ErrInvalidPagination {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener tareas")
 		return
 	}
 
-	resp := TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		StatusId:    task.StatusID,
-		PriorityId:  task.PriorityID,
-		StartsAt:    formatTime(task.StartsAt),
-		DueDate:     formatTime(task.DueDate),
-		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+	if !h.expandTasks(w, r, page.Tasks...) {
+		return
+	}
+
+	resp := make([]TaskResponse, 0, len(page.Tasks))
+	for _, task := range page.Tasks {
+		resp = append(resp, toTaskResponse(task))
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = service.DefaultPageLimit
+	}
+	meta := sharedhttp.PageMeta{Total: page.Total, Limit: limit}
+
+	if len(page.Tasks) > 0 {
+		first, last := page.Tasks[0], page.Tasks[len(page.Tasks)-1]
+		if page.HasNext {
+			meta.NextCursor, _ = h.cursorCodec.Encode(cursorPayload{CreatedAt: last.CreatedAt, ID: last.ID})
+		}
+		if page.HasPrev {
+			meta.PrevCursor, _ = h.cursorCodec.Encode(cursorPayload{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true})
+		}
 	}
 
-	sharedhttp.SuccessResponse(w, http.StatusCreated, resp)
+	sharedhttp.PaginatedResponse(w, http.StatusOK, resp, meta)
 }
 
-// GetTasks - GET /api/tasks
-func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
+// SearchTasks - GET /api/tasks/search?q=
+func (h *TaskHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
 	userID := sharedContext.GetUserID(r.Context())
+	query := r.URL.Query()
 
-	tasks, err := h.taskService.GetTasksByUserID(userID)
+	limit, err := parseIntParam(query, "limit")
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener tareas")
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	offset, err := parseIntParam(query, "offset")
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	var resp []TaskResponse
-	for _, task := range tasks {
-		resp = append(resp, TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			StatusId:    task.StatusID,
-			PriorityId:  task.PriorityID,
-			StartsAt:    task.StartsAt.Format(time.RFC3339),
-			DueDate:     task.DueDate.Format(time.RFC3339),
-			CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+	results, total, err := h.taskService.SearchTasks(userID, query.Get("q"), limit, offset)
+	if err != nil {
+		if err == service.ErrEmptySearchQuery || err == service.ErrInvalidPagination {
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al buscar tareas")
+		return
+	}
+
+	tasks := make([]*model.Task, 0, len(results))
+	for _, result := range results {
+		tasks = append(tasks, result.Task)
+	}
+	if !h.expandTasks(w, r, tasks...) {
+		return
+	}
+
+	resp := make([]TaskSearchResponse, 0, len(results))
+	for _, result := range results {
+		resp = append(resp, TaskSearchResponse{
+			TaskResponse: toTaskResponse(result.Task),
+			Rank:         result.Rank,
+			Highlight: SearchHighlight{
+				Title:       result.TitleHighlight,
+				Description: result.DescriptionSnippet,
+			},
 		})
 	}
 
-	sharedhttp.SuccessResponse(w, http.StatusOK, resp)
+	if limit == 0 {
+		limit = service.DefaultPageLimit
+	}
+
+	sharedhttp.PaginatedResponse(w, http.StatusOK, resp, sharedhttp.PageMeta{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
 }
 
 // GetTask - GET /api/tasks/{id}
@@ -143,27 +296,206 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		StatusId:    task.StatusID,
-		PriorityId:  task.PriorityID,
-		StartsAt:    task.StartsAt.Format(time.RFC3339),
-		DueDate:     task.DueDate.Format(time.RFC3339),
-		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+	if !h.expandTasks(w, r, task) {
+		return
 	}
 
-	sharedhttp.SuccessResponse(w, http.StatusOK, resp)
+	writeTaskResponse(w, r, http.StatusOK, task, toTaskResponse(task))
 }
 
-// PUT /api/tasks/{id}
+// PUT /api/tasks/{id}?scope=this|series&force=true (admite If-Match)
 func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	userID := sharedContext.GetUserID(r.Context())
 	taskID := chi.URLParam(r, "id")
 
-	var req TaskRequest
+	version, err := parseIfMatch(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	taskData, err := h.decodeTaskRequest(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	taskData.ID = taskID
+	taskData.Version = version
+
+	scope := model.EditScope(r.URL.Query().Get("scope"))
+	force := r.URL.Query().Get("force") == "true"
+	updatedTask, err := h.taskService.UpdateTask(taskData, userID, scope, force, sharedContext.GetRequestID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return			
+	}
+
+	if !h.expandTasks(w, r, updatedTask) {
+		return
+	}
+
+	writeTaskResponse(w, r, http.StatusOK, updatedTask, toTaskResponse(updatedTask))
+}
+
+// PatchTask - PATCH /api/tasks/{id}?scope=this|series&force=true (JSON Merge Patch, admite If-Match)
+func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	patch, err := decodeTaskPatch(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	patch.Version = version
+
+	scope := model.EditScope(r.URL.Query().Get("scope"))
+	force := r.URL.Query().Get("force") == "true"
+	task, err := h.taskService.PatchTask(chi.URLParam(r, "id"), patch, userID, scope, force, sharedContext.GetRequestID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	if !h.expandTasks(w, r, task) {
+		return
+	}
+
+	writeTaskResponse(w, r, http.StatusOK, task, toTaskResponse(task))
+}
+
+// CreateSubtask - POST /api/tasks/{id}/subtasks
+func (h *TaskHandler) CreateSubtask(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	parentID := chi.URLParam(r, "id")
+
+	taskData, err := h.decodeTaskRequest(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	task, err := h.taskService.CreateSubtask(parentID, taskData, userID, sharedContext.GetRequestID(r.Context()))
+	if err != nil {
+		status := taskErrorStatus(err)
+		if err == service.ErrInvalidParent {
+			status = http.StatusNotFound
+		}
+		sharedhttp.ErrorResponse(w, status, err.Error())
+		return
+	}
+
+	if !h.expandTasks(w, r, task) {
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusCreated, toTaskResponse(task))
+}
+
+// GetSubtasks - GET /api/tasks/{id}/subtasks
+func (h *TaskHandler) GetSubtasks(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	parentID := chi.URLParam(r, "id")
+
+	subtasks, err := h.taskService.GetSubtasks(parentID, userID)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !h.expandTasks(w, r, subtasks...) {
+		return
+	}
+
+	resp := make([]TaskResponse, 0, len(subtasks))
+	for _, task := range subtasks {
+		resp = append(resp, toTaskResponse(task))
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, resp)
+}
+
+// GetOccurrences - GET /api/tasks/{id}/occurrences?count=N
+func (h *TaskHandler) GetOccurrences(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	count, err := parseIntParam(r.URL.Query(), "count")
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	occurrences, err := h.taskService.GetUpcomingOccurrences(chi.URLParam(r, "id"), userID, count)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	resp := make([]OccurrenceResponse, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		resp = append(resp, OccurrenceResponse{
+			Occurrence: occurrence.Occurrence,
+			StartsAt:   formatTime(occurrence.StartsAt),
+			DueDate:    formatTime(occurrence.DueDate),
+		})
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, resp)
+}
+
+type MoveTaskRequest struct {
+	ProjectId string `json:"projectId"`
+}
+
+// MoveTask - POST /api/tasks/{id}/move
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req MoveTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	task, err := h.taskService.MoveTask(chi.URLParam(r, "id"), req.ProjectId, userID, sharedContext.GetRequestID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	if !h.expandTasks(w, r, task) {
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, toTaskResponse(task))
+}
+
+type ChangeStatusRequest struct {
+	StatusId int `json:"statusId" validate:"required,min=1"`
+	// Force permite empezar o terminar la tarea aunque tenga bloqueadoras abiertas
+	Force bool `json:"force"`
+}
+
+type ChangePriorityRequest struct {
+	PriorityId int `json:"priorityId" validate:"required,min=1"`
+}
+
+// ChangeStatus - PATCH /api/tasks/{id}/status (admite If-Match)
+func (h *TaskHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	var req ChangeStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
 		return
@@ -174,49 +506,366 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+	task, err := h.taskService.ChangeStatus(chi.URLParam(r, "id"), userID, req.StatusId, version, req.Force, sharedContext.GetRequestID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	if !h.expandTasks(w, r, task) {
+		return
+	}
+
+	writeTaskResponse(w, r, http.StatusOK, task, toTaskResponse(task))
+}
+
+// ChangePriority - PATCH /api/tasks/{id}/priority (admite If-Match)
+func (h *TaskHandler) ChangePriority(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	version, err := parseIfMatch(r)
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "StartsAt inválido")
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	var req ChangePriorityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
 		return
 	}
-	dueDate, err := time.Parse(time.RFC3339, req.DueDate)
+
+	task, err := h.taskService.ChangePriority(chi.URLParam(r, "id"), userID, req.PriorityId, version, sharedContext.GetRequestID(r.Context()))
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "DueDate inválido")
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
 		return
 	}
 
-	updatedTask, err := h.taskService.UpdateTask(&model.Task{
-		ID:          taskID,
-		Title:       req.Title,
-		Description: req.Description,
-		StatusID:    req.StatusId,
-		PriorityID:  req.PriorityId,
-		StartsAt:    startsAt,
-		DueDate:     dueDate,
-	}, userID)
+	if !h.expandTasks(w, r, task) {
+		return
+	}
+
+	writeTaskResponse(w, r, http.StatusOK, task, toTaskResponse(task))
+}
+
+// GetProjectTasks - GET /api/projects/{id}/tasks (admite los filtros de GET /api/tasks)
+func (h *TaskHandler) GetProjectTasks(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	filter, err := parseTaskFilter(r.URL.Query())
 	if err != nil {
 		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
-		return			
+		return
+	}
+
+	tasks, total, err := h.taskService.ListProjectTasks(chi.URLParam(r, "id"), userID, filter)
+	if err != nil {
+		switch err {
+		case service.ErrProjectNotFound:
+			sharedhttp.ErrorResponse(w, http.StatusNotFound, err.Error())
+		case service.ErrForbidden:
+			sharedhttp.ErrorResponse(w, http.StatusForbidden, err.Error())
+		case service.ErrInvalidPagination:
+			sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		default:
+			sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener tareas")
+		}
+		return
+	}
+
+	if !h.expandTasks(w, r, tasks...) {
+		return
 	}
 
-	sharedhttp.SuccessResponse(w, http.StatusOK, updatedTask)
+	resp := make([]TaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		resp = append(resp, toTaskResponse(task))
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = service.DefaultPageLimit
+	}
+
+	sharedhttp.PaginatedResponse(w, http.StatusOK, resp, sharedhttp.PageMeta{
+		Total:  total,
+		Limit:  limit,
+		Offset: filter.Offset,
+	})
 }
 
-// DELETE /api/tasks/{id}
+// DELETE /api/tasks/{id} (admite If-Match)
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	userID := sharedContext.GetUserID(r.Context())
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
 	taskID := chi.URLParam(r, "id")
-	err := h.taskService.DeleteTask(taskID, userID)
+	err = h.taskService.DeleteTask(taskID, userID, version, sharedContext.GetRequestID(r.Context()))
 	if err != nil {
-		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
 		return
 	}
 
 	sharedhttp.SuccessResponse(w, http.StatusNoContent, nil)
 }
 
+type BulkRequest struct {
+	TaskIds    []string `json:"ids" validate:"required,min=1,max=100"`
+	Operation  string   `json:"operation" validate:"required,oneof=set_status set_priority move add_label delete"`
+	StatusId   int      `json:"statusId" validate:"omitempty,min=1"`
+	PriorityId int      `json:"priorityId" validate:"omitempty,min=1"`
+	ProjectId  string   `json:"projectId"`
+	LabelId    string   `json:"labelId"`
+}
+
+// BulkUpdate - POST /api/tasks/bulk
+// Aplica la operación a todas las tareas o a ninguna; si alguna falla responde 422
+// con el resultado de cada tarea.
+func (h *TaskHandler) BulkUpdate(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	var req BulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, "JSON inválido")
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, format.FormatValidationError(err))
+		return
+	}
+
+	result, err := h.taskService.BulkUpdate(&model.BulkOperation{
+		Action:     model.BulkAction(req.Operation),
+		TaskIDs:    req.TaskIds,
+		StatusID:   req.StatusId,
+		PriorityID: req.PriorityId,
+		ProjectID:  req.ProjectId,
+		LabelID:    req.LabelId,
+	}, userID, sharedContext.GetRequestID(r.Context()))
+	switch {
+	case err == service.ErrBulkFailed:
+		sharedhttp.ErrorResponseWithData(w, http.StatusUnprocessableEntity, err.Error(), result)
+	case err == service.ErrLabelNotFound:
+		sharedhttp.ErrorResponse(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+	default:
+		sharedhttp.SuccessResponse(w, http.StatusOK, result)
+	}
+}
+
+// GetTrash - GET /api/tasks/trash?limit=&offset=
+func (h *TaskHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	query := r.URL.Query()
+
+	limit, err := parseIntParam(query, "limit")
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	offset, err := parseIntParam(query, "offset")
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tasks, total, err := h.taskService.GetTrash(userID, limit, offset)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	if !h.expandTasks(w, r, tasks...) {
+		return
+	}
+
+	resp := make([]TaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		resp = append(resp, toTaskResponse(task))
+	}
+
+	if limit == 0 {
+		limit = service.DefaultPageLimit
+	}
+
+	sharedhttp.PaginatedResponse(w, http.StatusOK, resp, sharedhttp.PageMeta{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// RestoreTask - POST /api/tasks/{id}/restore
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+
+	task, err := h.taskService.RestoreTask(chi.URLParam(r, "id"), userID, sharedContext.GetRequestID(r.Context()))
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	if !h.expandTasks(w, r, task) {
+		return
+	}
+
+	sharedhttp.SuccessResponse(w, http.StatusOK, toTaskResponse(task))
+}
+
+// GetHistory - GET /api/tasks/{id}/history?limit=&offset=
+func (h *TaskHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userID := sharedContext.GetUserID(r.Context())
+	query := r.URL.Query()
+
+	limit, err := parseIntParam(query, "limit")
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	offset, err := parseIntParam(query, "offset")
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, total, err := h.taskService.GetHistory(chi.URLParam(r, "id"), userID, limit, offset)
+	if err != nil {
+		sharedhttp.ErrorResponse(w, taskErrorStatus(err), err.Error())
+		return
+	}
+
+	if limit == 0 {
+		limit = service.DefaultPageLimit
+	}
+
+	sharedhttp.PaginatedResponse(w, http.StatusOK, events, sharedhttp.PageMeta{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
 // ------------------------- HELPERS ------------------------- //
+
+// taskErrorStatus traduce los errores de autorización y de flujo del servicio; el resto son 400
+func taskErrorStatus(err error) int {
+	switch err {
+	case service.ErrTaskNotFound, service.ErrDependencyNotFound:
+		return http.StatusNotFound
+	case service.ErrUnauthorized, service.ErrForbidden:
+		return http.StatusForbidden
+	case service.ErrInvalidTransition, service.ErrParentInTrash,
+		service.ErrTaskBlocked, service.ErrDependencyCycle, service.ErrDependencyExists:
+		return http.StatusConflict
+	case service.ErrVersionMismatch:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// expandTasks carga en las tareas las relaciones pedidas con ?expand=, con los nombres
+// en el idioma de Accept-Language. Si falla ya respondió con el error.
+func (h *TaskHandler) expandTasks(w http.ResponseWriter, r *http.Request, tasks ...*model.Task) bool {
+	expand, err := parseTaskExpand(r.URL.Query())
+	if err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+	if err := h.taskService.ExpandCatalog(tasks, expand, lang); err != nil {
+		sharedhttp.ErrorResponse(w, http.StatusInternalServerError, "Error al obtener el catálogo")
+		return false
+	}
+	return true
+}
+
+// decodeTaskRequest lee y valida el cuerpo TaskRequest y lo convierte a model.Task
+func (h *TaskHandler) decodeTaskRequest(r *http.Request) (*model.Task, error) {
+	var req TaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.New("JSON inválido")
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return nil, errors.New(format.FormatValidationError(err))
+	}
+
+	startsAt, err := parseOptionalTime(req.StartsAt)
+	if err != nil {
+		return nil, errors.New("StartsAt inválido")
+	}
+
+	dueDate, err := parseOptionalTime(req.DueDate)
+	if err != nil {
+		return nil, errors.New("DueDate inválido")
+	}
+
+	return &model.Task{
+		ParentID:    req.ParentId,
+		ProjectID:   req.ProjectId,
+		Title:       req.Title,
+		Description: req.Description,
+		StatusID:    req.StatusId,
+		PriorityID:  req.PriorityId,
+		StartsAt:    startsAt,
+		DueDate:     dueDate,
+		RecurrenceRule: req.RecurrenceRule,
+	}, nil
+}
+
+func toTaskResponse(task *model.Task) TaskResponse {
+	labels := task.Labels
+	if labels == nil {
+		labels = []*model.Label{}
+	}
+
+	return TaskResponse{
+		ID:          task.ID,
+		ParentId:    task.ParentID,
+		ProjectId:   task.ProjectID,
+		Title:       task.Title,
+		Description: task.Description,
+		StatusId:    task.StatusID,
+		PriorityId:  task.PriorityID,
+		Version:     task.Version,
+		Progress:    task.Progress,
+		Labels:      labels,
+		StartsAt:    formatTime(task.StartsAt),
+		DueDate:     formatTime(task.DueDate),
+		RecurrenceRule: task.RecurrenceRule,
+		SeriesId:    task.SeriesID,
+		Occurrence:  task.Occurrence,
+		CompletedAt: formatTime(task.CompletedAt),
+		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+		DeletedAt:   formatTime(task.DeletedAt),
+		Status:      task.Status,
+		Priority:    task.Priority,
+	}
+}
+
+// parseOptionalTime interpreta una fecha RFC3339; vacía = sin fecha (fecha cero)
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 func formatTime(t time.Time) string {
 	if t.IsZero() {
 		return ""
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/http"
+	"time"
+)
+
+// decodeTaskPatch interpreta el cuerpo como JSON Merge Patch (RFC 7396): los campos
+// ausentes no cambian y null los vacía. Vaciar un campo obligatorio (title, statusId,
+// priorityId) lo rechaza el servicio al validarlo.
+func decodeTaskPatch(r *http.Request) (*model.TaskPatch, error) {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil || fields == nil {
+		return nil, errors.New("JSON inválido")
+	}
+
+	patch := &model.TaskPatch{}
+	for name, raw := range fields {
+		var err error
+		switch name {
+		case "parentId":
+			patch.ParentID, err = patchString(name, raw)
+		case "projectId":
+			patch.ProjectID, err = patchString(name, raw)
+		case "title":
+			patch.Title, err = patchString(name, raw)
+		case "description":
+			patch.Description, err = patchString(name, raw)
+		case "statusId":
+			patch.StatusID, err = patchInt(name, raw)
+		case "priorityId":
+			patch.PriorityID, err = patchInt(name, raw)
+		case "startsAt":
+			patch.StartsAt, err = patchTime(name, raw)
+		case "dueDate":
+			patch.DueDate, err = patchTime(name, raw)
+		case "recurrenceRule":
+			patch.RecurrenceRule, err = patchString(name, raw)
+		default:
+			err = fmt.Errorf("el campo '%s' no se puede modificar", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return patch, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+func patchString(name string, raw json.RawMessage) (*string, error) {
+	var value string
+	if isJSONNull(raw) {
+		return &value, nil
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("el campo '%s' debe ser un texto", name)
+	}
+	return &value, nil
+}
+
+func patchInt(name string, raw json.RawMessage) (*int, error) {
+	var value int
+	if isJSONNull(raw) {
+		return &value, nil
+	}
+	if err := json.Unmarshal(raw, &value); err != nil || value < 1 {
+		return nil, fmt.Errorf("el campo '%s' debe ser un número mayor que 0", name)
+	}
+	return &value, nil
+}
+
+func patchTime(name string, raw json.RawMessage) (*time.Time, error) {
+	var value time.Time
+	if isJSONNull(raw) {
+		return &value, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, fmt.Errorf("%s inválido", name)
+	}
+	value, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return nil, fmt.Errorf("%s inválido", name)
+	}
+	return &value, nil
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newPatchRequest(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPatch, "/api/tasks/t1", strings.NewReader(body))
+}
+
+func TestDecodeTaskPatch(t *testing.T) {
+	due := time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC)
+	base := model.Task{
+		ID:          "t1",
+		ProjectID:   "p1",
+		Title:       "Informe",
+		Description: "borrador",
+		StatusID:    2,
+		PriorityID:  3,
+		DueDate:     due,
+	}
+	with := func(change func(task *model.Task)) model.Task {
+		task := base
+		change(&task)
+		return task
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    model.Task
+		wantErr bool
+	}{
+		{name: "objeto vacío no cambia nada", body: `{}`, want: base},
+		{
+			name: "null vacía el campo",
+			body: `{"description": null}`,
+			want: with(func(task *model.Task) { task.Description = "" }),
+		},
+		{
+			name: "null en una fecha la quita",
+			body: `{"dueDate": null, "title": "Informe final"}`,
+			want: with(func(task *model.Task) { task.DueDate = time.Time{}; task.Title = "Informe final" }),
+		},
+		{
+			name: "fecha nueva",
+			body: `{"startsAt": "2026-01-20T08:00:00Z"}`,
+			want: with(func(task *model.Task) { task.StartsAt = time.Date(2026, time.January, 20, 8, 0, 0, 0, time.UTC) }),
+		},
+		{
+			name: "null en un campo obligatorio llega vacío al servicio",
+			body: `{"statusId": null, "projectId": null}`,
+			want: with(func(task *model.Task) { task.StatusID = 0; task.ProjectID = "" }),
+		},
+		{name: "campo no modificable", body: `{"userId": "u2"}`, wantErr: true},
+		{name: "identificador cero", body: `{"priorityId": 0}`, wantErr: true},
+		{name: "identificador como texto", body: `{"statusId": "2"}`, wantErr: true},
+		{name: "fecha mal formada", body: `{"dueDate": "mañana"}`, wantErr: true},
+		{name: "texto como número", body: `{"title": 5}`, wantErr: true},
+		{name: "cuerpo null", body: `null`, wantErr: true},
+		{name: "cuerpo que no es un objeto", body: `[]`, wantErr: true},
+		{name: "JSON inválido", body: `{"title":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := decodeTaskPatch(newPatchRequest(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodeTaskPatch(%s) no falló", tt.body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeTaskPatch(%s): %v", tt.body, err)
+			}
+
+			got := base
+			patch.Apply(&got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tras aplicar %s:\n got  %+v\n want %+v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTaskPatchRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want *string // nil = ausente
+	}{
+		{name: "ausente", body: `{"title": "x"}`, want: nil},
+		{name: "null termina la serie", body: `{"recurrenceRule": null}`, want: ptr("")},
+		{name: "regla nueva", body: `{"recurrenceRule": "FREQ=DAILY"}`, want: ptr("FREQ=DAILY")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := decodeTaskPatch(newPatchRequest(tt.body))
+			if err != nil {
+				t.Fatalf("decodeTaskPatch(%s): %v", tt.body, err)
+			}
+			got := patch.RecurrenceRule
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("RecurrenceRule = %v, se esperaba %v", deref(got), deref(tt.want))
+			}
+		})
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return "<ausente>"
+	}
+	return *value
+}
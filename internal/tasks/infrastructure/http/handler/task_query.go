@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"fmt"
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var sortFields = map[string]model.TaskSortField{
+	"createdAt": model.SortByCreatedAt,
+	"dueDate":   model.SortByDueDate,
+	"priority":  model.SortByPriority,
+}
+
+// cursorPayload - Contenido del cursor opaco de paginación por keyset
+type cursorPayload struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+func isCursorPagination(query url.Values) bool {
+	return query.Get("cursor") != "" || query.Get("pagination") == "cursor"
+}
+
+// parseTaskExpand interpreta ?expand=status,priority
+func parseTaskExpand(query url.Values) (model.TaskExpand, error) {
+	var expand model.TaskExpand
+
+	raw := query.Get("expand")
+	if raw == "" {
+		return expand, nil
+	}
+	for _, field := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(field) {
+		case "status":
+			expand.Status = true
+		case "priority":
+			expand.Priority = true
+		case "":
+		default:
+			return expand, fmt.Errorf("expand inválido: %q (usar status, priority)", field)
+		}
+	}
+	return expand, nil
+}
+
+// parseTaskFilter construye un model.TaskFilter a partir de los query params de GET /api/tasks
+func parseTaskFilter(query url.Values) (model.TaskFilter, error) {
+	var filter model.TaskFilter
+	var err error
+
+	if filter.StatusIDs, err = parseIntList(query, "status"); err != nil {
+		return filter, err
+	}
+	if filter.PriorityIDs, err = parseIntList(query, "priority"); err != nil {
+		return filter, err
+	}
+
+	dateParams := []struct {
+		name   string
+		target **time.Time
+	}{
+		{"dueFrom", &filter.DueFrom},
+		{"dueTo", &filter.DueTo},
+		{"createdFrom", &filter.CreatedFrom},
+		{"createdTo", &filter.CreatedTo},
+		{"updatedFrom", &filter.UpdatedFrom},
+		{"updatedTo", &filter.UpdatedTo},
+	}
+	for _, param := range dateParams {
+		if *param.target, err = parseTimeParam(query, param.name); err != nil {
+			return filter, err
+		}
+	}
+
+	if raw := query.Get("hasDueDate"); raw != "" {
+		hasDueDate, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, fmt.Errorf("parámetro 'hasDueDate' inválido")
+		}
+		filter.HasDueDate = &hasDueDate
+	}
+
+	if raw := query.Get("label"); raw != "" {
+		for _, labelID := range strings.Split(raw, ",") {
+			if labelID = strings.TrimSpace(labelID); labelID != "" {
+				filter.LabelIDs = append(filter.LabelIDs, labelID)
+			}
+		}
+	}
+
+	filter.Text = strings.TrimSpace(query.Get("q"))
+
+	if filter.Sort, err = parseSort(query.Get("sort")); err != nil {
+		return filter, err
+	}
+
+	if filter.Limit, err = parseIntParam(query, "limit"); err != nil {
+		return filter, err
+	}
+	if filter.Offset, err = parseIntParam(query, "offset"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func parseIntList(query url.Values, name string) ([]int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var values []int
+	for _, part := range strings.Split(raw, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("parámetro '%s' inválido", name)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func parseIntParam(query url.Values, name string) (int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("parámetro '%s' inválido", name)
+	}
+	return value, nil
+}
+
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("parámetro '%s' inválido, se espera formato RFC3339", name)
+	}
+	return &value, nil
+}
+
+// parseSort interpreta "sort=dueDate,-priority" (prefijo "-" = descendente)
+func parseSort(raw string) ([]model.TaskSort, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var sorts []model.TaskSort
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		field, ok := sortFields[strings.TrimPrefix(part, "-")]
+		if !ok {
+			return nil, fmt.Errorf("campo de ordenamiento '%s' no soportado", part)
+		}
+		sorts = append(sorts, model.TaskSort{Field: field, Desc: desc})
+	}
+	return sorts, nil
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTaskFilter(t *testing.T) {
+	dueFrom := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	hasDueDate := false
+
+	tests := []struct {
+		name    string
+		query   string
+		want    model.TaskFilter
+		wantErr bool
+	}{
+		{name: "sin parámetros", query: "", want: model.TaskFilter{}},
+		{
+			name:  "todos los filtros",
+			query: "status=1,2&priority=3&label=l1,,%20l2&dueFrom=2026-01-01T00:00:00Z&hasDueDate=false&q=%20informe%20&sort=dueDate,-priority&limit=10&offset=20",
+			want: model.TaskFilter{
+				StatusIDs:   []int{1, 2},
+				PriorityIDs: []int{3},
+				LabelIDs:    []string{"l1", "l2"},
+				DueFrom:     &dueFrom,
+				HasDueDate:  &hasDueDate,
+				Text:        "informe",
+				Sort: []model.TaskSort{
+					{Field: model.SortByDueDate},
+					{Field: model.SortByPriority, Desc: true},
+				},
+				Limit:  10,
+				Offset: 20,
+			},
+		},
+		{name: "estado no numérico", query: "status=1,x", wantErr: true},
+		{name: "fecha sin zona", query: "dueTo=2026-01-01", wantErr: true},
+		{name: "hasDueDate inválido", query: "hasDueDate=quizá", wantErr: true},
+		{name: "orden no soportado", query: "sort=title", wantErr: true},
+		{name: "limit no numérico", query: "limit=diez", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			filter, err := parseTaskFilter(query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseTaskFilter(%q) no falló", tt.query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTaskFilter(%q): %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(filter, tt.want) {
+				t.Errorf("parseTaskFilter(%q):\n got  %+v\n want %+v", tt.query, filter, tt.want)
+			}
+		})
+	}
+}
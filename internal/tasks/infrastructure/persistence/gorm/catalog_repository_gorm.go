@@ -0,0 +1,204 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type CatalogRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewCatalogRepository(db *gorm.DB) *CatalogRepositoryGorm {
+	return &CatalogRepositoryGorm{db: db}
+}
+
+// ------------------------- Estados ------------------------- //
+
+func (r *CatalogRepositoryGorm) FindStatuses(scope model.CatalogScope) ([]*model.TaskStatus, error) {
+	var statusModels []TaskStatusModel
+	if err := scopeQuery(r.db, scope).Order("id ASC").Find(&statusModels).Error; err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*model.TaskStatus, 0, len(statusModels))
+	for i := range statusModels {
+		statuses = append(statuses, toDomainStatus(&statusModels[i]))
+	}
+	return statuses, nil
+}
+
+func (r *CatalogRepositoryGorm) FindStatusByID(id int) (*model.TaskStatus, error) {
+	var statusModel TaskStatusModel
+	if err := r.db.First(&statusModel, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return toDomainStatus(&statusModel), nil
+}
+
+func (r *CatalogRepositoryGorm) FindStatusesByIDs(ids []int) (map[int]*model.TaskStatus, error) {
+	statuses := make(map[int]*model.TaskStatus, len(ids))
+	if len(ids) == 0 {
+		return statuses, nil
+	}
+
+	var statusModels []TaskStatusModel
+	if err := r.db.Where("id IN ?", ids).Find(&statusModels).Error; err != nil {
+		return nil, err
+	}
+	for i := range statusModels {
+		statuses[statusModels[i].ID] = toDomainStatus(&statusModels[i])
+	}
+	return statuses, nil
+}
+
+func (r *CatalogRepositoryGorm) CreateStatus(status *model.TaskStatus) error {
+	statusModel := toStatusModel(status)
+	if err := r.db.Create(statusModel).Error; err != nil {
+		return err
+	}
+	status.ID = statusModel.ID
+	return nil
+}
+
+func (r *CatalogRepositoryGorm) UpdateStatus(status *model.TaskStatus) error {
+	return r.db.Save(toStatusModel(status)).Error
+}
+
+func (r *CatalogRepositoryGorm) DeleteStatus(id, reassignTo int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Unscoped: también las tareas en la papelera
+		if err := tx.Unscoped().Model(&TaskModel{}).Where("status_id = ?", id).
+			Updates(map[string]interface{}{"status_id": reassignTo, "version": nextVersion()}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&TaskStatusModel{}, "id = ?", id).Error
+	})
+}
+
+// ------------------------- Prioridades ------------------------- //
+
+func (r *CatalogRepositoryGorm) FindPriorities(scope model.CatalogScope) ([]*model.TaskPriority, error) {
+	var priorityModels []TaskPriorityModel
+	if err := scopeQuery(r.db, scope).Order("level ASC").Order("id ASC").Find(&priorityModels).Error; err != nil {
+		return nil, err
+	}
+
+	priorities := make([]*model.TaskPriority, 0, len(priorityModels))
+	for i := range priorityModels {
+		priorities = append(priorities, toDomainPriority(&priorityModels[i]))
+	}
+	return priorities, nil
+}
+
+func (r *CatalogRepositoryGorm) FindPriorityByID(id int) (*model.TaskPriority, error) {
+	var priorityModel TaskPriorityModel
+	if err := r.db.First(&priorityModel, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return toDomainPriority(&priorityModel), nil
+}
+
+func (r *CatalogRepositoryGorm) FindPrioritiesByIDs(ids []int) (map[int]*model.TaskPriority, error) {
+	priorities := make(map[int]*model.TaskPriority, len(ids))
+	if len(ids) == 0 {
+		return priorities, nil
+	}
+
+	var priorityModels []TaskPriorityModel
+	if err := r.db.Where("id IN ?", ids).Find(&priorityModels).Error; err != nil {
+		return nil, err
+	}
+	for i := range priorityModels {
+		priorities[priorityModels[i].ID] = toDomainPriority(&priorityModels[i])
+	}
+	return priorities, nil
+}
+
+func (r *CatalogRepositoryGorm) CreatePriority(priority *model.TaskPriority) error {
+	priorityModel := toPriorityModel(priority)
+	if err := r.db.Create(priorityModel).Error; err != nil {
+		return err
+	}
+	priority.ID = priorityModel.ID
+	return nil
+}
+
+func (r *CatalogRepositoryGorm) UpdatePriority(priority *model.TaskPriority) error {
+	return r.db.Save(toPriorityModel(priority)).Error
+}
+
+func (r *CatalogRepositoryGorm) DeletePriority(id, reassignTo int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Unscoped: también las tareas en la papelera
+		if err := tx.Unscoped().Model(&TaskModel{}).Where("priority_id = ?", id).
+			Updates(map[string]interface{}{"priority_id": reassignTo, "version": nextVersion()}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&TaskSeriesModel{}).Where("priority_id = ?", id).Update("priority_id", reassignTo).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&TaskPriorityModel{}, "id = ?", id).Error
+	})
+}
+
+// ------------------- Helper ---------------------
+
+// scopeQuery filtra las entradas del sistema y las del ámbito (proyecto o usuario)
+func scopeQuery(db *gorm.DB, scope model.CatalogScope) *gorm.DB {
+	if scope.ProjectID != "" {
+		return db.Where("(user_id IS NULL AND project_id IS NULL) OR project_id = ?", scope.ProjectID)
+	}
+	return db.Where("(user_id IS NULL AND project_id IS NULL) OR (user_id = ? AND project_id IS NULL)", scope.UserID)
+}
+
+func toStatusModel(status *model.TaskStatus) *TaskStatusModel {
+	return &TaskStatusModel{
+		ID:          status.ID,
+		Code:        nullableString(status.Code),
+		Name:        status.Name,
+		Description: status.Description,
+		Category:    string(status.Category),
+		UserID:      nullableString(status.UserID),
+		ProjectID:   nullableString(status.ProjectID),
+		CreatedAt:   status.CreatedAt,
+	}
+}
+
+func toDomainStatus(sm *TaskStatusModel) *model.TaskStatus {
+	return &model.TaskStatus{
+		ID:          sm.ID,
+		Code:        derefString(sm.Code),
+		Name:        sm.Name,
+		Description: sm.Description,
+		Category:    model.StatusCategory(sm.Category),
+		UserID:      derefString(sm.UserID),
+		ProjectID:   derefString(sm.ProjectID),
+		CreatedAt:   sm.CreatedAt,
+	}
+}
+
+func toPriorityModel(priority *model.TaskPriority) *TaskPriorityModel {
+	return &TaskPriorityModel{
+		ID:        priority.ID,
+		Code:      nullableString(priority.Code),
+		Name:      priority.Name,
+		Level:     priority.Level,
+		UserID:    nullableString(priority.UserID),
+		ProjectID: nullableString(priority.ProjectID),
+		CreatedAt: priority.CreatedAt,
+	}
+}
+
+func toDomainPriority(pm *TaskPriorityModel) *model.TaskPriority {
+	return &model.TaskPriority{
+		ID:        pm.ID,
+		Code:      derefString(pm.Code),
+		Name:      pm.Name,
+		Level:     pm.Level,
+		UserID:    derefString(pm.UserID),
+		ProjectID: derefString(pm.ProjectID),
+		CreatedAt: pm.CreatedAt,
+	}
+}
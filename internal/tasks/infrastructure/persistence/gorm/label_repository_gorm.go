@@ -0,0 +1,139 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LabelRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) *LabelRepositoryGorm {
+	return &LabelRepositoryGorm{db: db}
+}
+
+func (r *LabelRepositoryGorm) Create(label *model.Label) error {
+	return r.db.Create(toLabelModel(label)).Error
+}
+
+func (r *LabelRepositoryGorm) FindByID(id string) (*model.Label, error) {
+	var labelModel LabelModel
+	if err := r.db.First(&labelModel, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return toDomainLabel(&labelModel), nil
+}
+
+func (r *LabelRepositoryGorm) FindByUserID(userID string) ([]*model.Label, error) {
+	var labelModels []LabelModel
+	if err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&labelModels).Error; err != nil {
+		return nil, err
+	}
+
+	labels := make([]*model.Label, 0, len(labelModels))
+	for i := range labelModels {
+		labels = append(labels, toDomainLabel(&labelModels[i]))
+	}
+	return labels, nil
+}
+
+func (r *LabelRepositoryGorm) FindByUserAndName(userID, name string) (*model.Label, error) {
+	var labelModel LabelModel
+	if err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&labelModel).Error; err != nil {
+		return nil, err
+	}
+	return toDomainLabel(&labelModel), nil
+}
+
+func (r *LabelRepositoryGorm) Update(label *model.Label) error {
+	return r.db.Save(toLabelModel(label)).Error
+}
+
+func (r *LabelRepositoryGorm) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("label_id = ?", id).Delete(&TaskLabelModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&LabelModel{}, "id = ?", id).Error
+	})
+}
+
+// AssignToTask - Asigna etiquetas a una tarea (ignora las que ya estaban asignadas)
+func (r *LabelRepositoryGorm) AssignToTask(taskID string, labelIDs []string) error {
+	if len(labelIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]TaskLabelModel, 0, len(labelIDs))
+	for _, labelID := range labelIDs {
+		rows = append(rows, TaskLabelModel{TaskID: taskID, LabelID: labelID})
+	}
+
+	return r.db.Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&rows).Error
+}
+
+func (r *LabelRepositoryGorm) RemoveFromTask(taskID, labelID string) error {
+	return r.db.Where("task_id = ? AND label_id = ?", taskID, labelID).Delete(&TaskLabelModel{}).Error
+}
+
+// FindByTaskIDs - Etiquetas de userID en varias tareas en una sola consulta, agrupadas
+// por tarea. Las etiquetas de otros usuarios (p. ej. en proyectos compartidos) no se incluyen.
+func (r *LabelRepositoryGorm) FindByTaskIDs(taskIDs []string, userID string) (map[string][]*model.Label, error) {
+	labelsByTask := make(map[string][]*model.Label)
+	if len(taskIDs) == 0 {
+		return labelsByTask, nil
+	}
+
+	var rows []struct {
+		LabelModel
+		TaskID string
+	}
+	if err := r.db.Table("labels").
+		Select("labels.*, task_labels.task_id").
+		Joins("JOIN task_labels ON task_labels.label_id = labels.id").
+		Where("task_labels.task_id IN ? AND labels.user_id = ?", taskIDs, userID).
+		Order("labels.name ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		labelsByTask[rows[i].TaskID] = append(labelsByTask[rows[i].TaskID], toDomainLabel(&rows[i].LabelModel))
+	}
+	return labelsByTask, nil
+}
+
+// FindIDsByTaskID - IDs de todas las etiquetas asignadas a la tarea, de cualquier usuario
+func (r *LabelRepositoryGorm) FindIDsByTaskID(taskID string) ([]string, error) {
+	var labelIDs []string
+	err := r.db.Model(&TaskLabelModel{}).Where("task_id = ?", taskID).Pluck("label_id", &labelIDs).Error
+	return labelIDs, err
+}
+
+// ------------------- Helper ---------------------
+func toLabelModel(label *model.Label) *LabelModel {
+	return &LabelModel{
+		ID:        label.ID,
+		UserID:    label.UserID,
+		Name:      label.Name,
+		Color:     label.Color,
+		CreatedAt: label.CreatedAt,
+		UpdatedAt: label.UpdatedAt,
+	}
+}
+
+func toDomainLabel(lm *LabelModel) *model.Label {
+	return &model.Label{
+		ID:        lm.ID,
+		UserID:    lm.UserID,
+		Name:      lm.Name,
+		Color:     lm.Color,
+		CreatedAt: lm.CreatedAt,
+		UpdatedAt: lm.UpdatedAt,
+	}
+}
@@ -1,12 +1,21 @@
 package gorm
 
-import "time"
+import (
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// TaskStatusModel - Catálogo de estados. Los del sistema tienen Code y no tienen
+// dueño; los personalizados pertenecen a un usuario o a un proyecto.
 type TaskStatusModel struct {
-	ID          int    `gorm:"primaryKey;autoIncrement"`
-	Code        string `gorm:"unique;not null"`
-	Name        string `gorm:"not null"`
+	ID          int     `gorm:"primaryKey;autoIncrement"`
+	Code        *string `gorm:"unique"`
+	Name        string  `gorm:"not null"`
 	Description string
+	Category    string    `gorm:"not null;default:open"`
+	UserID      *string   `gorm:"index"`
+	ProjectID   *string   `gorm:"index"`
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
 }
 
@@ -16,9 +25,11 @@ func (TaskStatusModel) TableName() string {
 
 type TaskPriorityModel struct {
 	ID        int       `gorm:"primaryKey;autoIncrement"`
-	Code      string    `gorm:"unique;not null"`
+	Code      *string   `gorm:"unique"`
 	Name      string    `gorm:"not null"`
 	Level     int       `gorm:"not null"`
+	UserID    *string   `gorm:"index"`
+	ProjectID *string   `gorm:"index"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 }
 
@@ -27,9 +38,11 @@ func (TaskPriorityModel) TableName() string {
 }
 
 type TaskModel struct {
-	ID          string `gorm:"primaryKey;type:text"`
-	UserID      string `gorm:"not null;index"`
-	Title       string `gorm:"not null"`
+	ID          string  `gorm:"primaryKey;type:text"`
+	UserID      string  `gorm:"not null;index"`
+	ParentID    *string `gorm:"type:text;index"`
+	ProjectID   *string `gorm:"type:text;index"`
+	Title       string  `gorm:"not null"`
 	Description string
 	StatusID    int `gorm:"not null;index"`
 	PriorityID  int `gorm:"not null;index"`
@@ -38,6 +51,13 @@ type TaskModel struct {
 	CompletedAt *time.Time
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	Version     int       `gorm:"not null;default:1"`
+	// Borrado lógico: GORM excluye de las consultas las tareas en la papelera
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	RecurrenceRule string
+	SeriesID       *string `gorm:"type:text;uniqueIndex:idx_tasks_series_occurrence"`
+	Occurrence     int     `gorm:"not null;default:0;uniqueIndex:idx_tasks_series_occurrence"`
 
 	// Relaciones (GORM cargará estos automáticamente con Preload)
 	Status   TaskStatusModel   `gorm:"foreignKey:StatusID"`
@@ -47,3 +67,136 @@ type TaskModel struct {
 func (TaskModel) TableName() string {
 	return "tasks"
 }
+
+// TaskSeriesModel - Plantilla de las tareas recurrentes
+type TaskSeriesModel struct {
+	ID             string  `gorm:"primaryKey;type:text"`
+	UserID         string  `gorm:"not null;index"`
+	ProjectID      *string `gorm:"type:text;index"`
+	Title          string  `gorm:"not null"`
+	Description    string
+	PriorityID     int    `gorm:"not null"`
+	RecurrenceRule string `gorm:"not null"`
+	StartsAt       *time.Time
+	DueDate        *time.Time
+	BaseOccurrence int       `gorm:"not null;default:1"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+func (TaskSeriesModel) TableName() string {
+	return "task_series"
+}
+
+// ReminderModel - Recordatorios de tareas; el estado persistido permite al
+// scheduler retomar los envíos tras un reinicio sin duplicarlos
+type ReminderModel struct {
+	ID            string `gorm:"primaryKey;type:text"`
+	TaskID        string `gorm:"not null;index"`
+	UserID        string `gorm:"not null;index"`
+	OffsetMinutes *int
+	RemindAt      time.Time `gorm:"not null;index:idx_reminders_due,priority:2"`
+	Channel       string    `gorm:"not null"`
+	Status        string    `gorm:"not null;index:idx_reminders_due,priority:1"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string
+	SentAt        *time.Time
+	LockedUntil   *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+
+	Task TaskModel `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE"`
+}
+
+func (ReminderModel) TableName() string {
+	return "reminders"
+}
+
+// TaskDependencyModel - BlockerID bloquea a BlockedID. Se conserva mientras las tareas
+// están en la papelera y se elimina al borrarlas definitivamente.
+type TaskDependencyModel struct {
+	BlockerID string    `gorm:"primaryKey;type:text"`
+	BlockedID string    `gorm:"primaryKey;type:text;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	Blocker TaskModel `gorm:"foreignKey:BlockerID;constraint:OnDelete:CASCADE"`
+	Blocked TaskModel `gorm:"foreignKey:BlockedID;constraint:OnDelete:CASCADE"`
+}
+
+func (TaskDependencyModel) TableName() string {
+	return "task_dependencies"
+}
+
+// TaskEventModel - Historial de cambios; sin clave foránea a tasks para que
+// sobreviva a la eliminación de la tarea. Changes guarda el diff en JSON.
+type TaskEventModel struct {
+	ID        string    `gorm:"primaryKey;type:text"`
+	TaskID    string    `gorm:"not null;index:idx_task_events_task,priority:1"`
+	ActorID   string    `gorm:"not null;index"`
+	Action    string    `gorm:"not null"`
+	Changes   string    `gorm:"type:text;not null"`
+	RequestID string    `gorm:"index"`
+	CreatedAt time.Time `gorm:"not null;index:idx_task_events_task,priority:2"`
+}
+
+func (TaskEventModel) TableName() string {
+	return "task_events"
+}
+
+type LabelModel struct {
+	ID        string    `gorm:"primaryKey;type:text"`
+	UserID    string    `gorm:"not null;index;uniqueIndex:idx_labels_user_name"`
+	Name      string    `gorm:"not null;uniqueIndex:idx_labels_user_name"`
+	Color     string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (LabelModel) TableName() string {
+	return "labels"
+}
+
+// TaskLabelModel - Tabla intermedia tasks <-> labels (muchos a muchos)
+type TaskLabelModel struct {
+	TaskID    string    `gorm:"primaryKey;type:text"`
+	LabelID   string    `gorm:"primaryKey;type:text;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	Task  TaskModel  `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE"`
+	Label LabelModel `gorm:"foreignKey:LabelID;constraint:OnDelete:CASCADE"`
+}
+
+func (TaskLabelModel) TableName() string {
+	return "task_labels"
+}
+
+type ProjectModel struct {
+	ID          string `gorm:"primaryKey;type:text"`
+	UserID      string `gorm:"not null;index"`
+	Name        string `gorm:"not null"`
+	Description string
+	Color       string    `gorm:"not null"`
+	Archived    bool      `gorm:"not null;default:false"`
+	Position    int       `gorm:"not null;default:0"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ProjectModel) TableName() string {
+	return "projects"
+}
+
+// ProjectMemberModel - Usuarios invitados a un proyecto y su rol.
+// El creador del proyecto (projects.user_id) es siempre owner y no necesita fila aquí.
+type ProjectMemberModel struct {
+	ProjectID string    `gorm:"primaryKey;type:text"`
+	UserID    string    `gorm:"primaryKey;type:text;index"`
+	Role      string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	Project ProjectModel `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+}
+
+func (ProjectMemberModel) TableName() string {
+	return "project_members"
+}
@@ -0,0 +1,82 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProjectMemberRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewProjectMemberRepository(db *gorm.DB) *ProjectMemberRepositoryGorm {
+	return &ProjectMemberRepositoryGorm{db: db}
+}
+
+// Save - Agrega el miembro o actualiza su rol si ya existía
+func (r *ProjectMemberRepositoryGorm) Save(member *model.ProjectMember) error {
+	memberModel := &ProjectMemberModel{
+		ProjectID: member.ProjectID,
+		UserID:    member.UserID,
+		Role:      string(member.Role),
+		CreatedAt: member.CreatedAt,
+	}
+
+	return r.db.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role"}),
+		}).
+		Create(memberModel).Error
+}
+
+// FindRole - Rol del usuario en el proyecto ("" si no es miembro)
+func (r *ProjectMemberRepositoryGorm) FindRole(projectID, userID string) (model.ProjectRole, error) {
+	var members []ProjectMemberModel
+	if err := r.db.Where("project_id = ? AND user_id = ?", projectID, userID).Limit(1).Find(&members).Error; err != nil {
+		return "", err
+	}
+
+	if len(members) == 0 {
+		return "", nil
+	}
+	return model.ProjectRole(members[0].Role), nil
+}
+
+// FindRolesByUserID - Proyectos compartidos con el usuario y su rol en cada uno
+func (r *ProjectMemberRepositoryGorm) FindRolesByUserID(userID string) (map[string]model.ProjectRole, error) {
+	var members []ProjectMemberModel
+	if err := r.db.Where("user_id = ?", userID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]model.ProjectRole, len(members))
+	for _, member := range members {
+		roles[member.ProjectID] = model.ProjectRole(member.Role)
+	}
+	return roles, nil
+}
+
+func (r *ProjectMemberRepositoryGorm) FindByProjectID(projectID string) ([]*model.ProjectMember, error) {
+	var memberModels []ProjectMemberModel
+	if err := r.db.Where("project_id = ?", projectID).Order("created_at ASC").Find(&memberModels).Error; err != nil {
+		return nil, err
+	}
+
+	members := make([]*model.ProjectMember, 0, len(memberModels))
+	for _, mm := range memberModels {
+		members = append(members, &model.ProjectMember{
+			ProjectID: mm.ProjectID,
+			UserID:    mm.UserID,
+			Role:      model.ProjectRole(mm.Role),
+			CreatedAt: mm.CreatedAt,
+		})
+	}
+	return members, nil
+}
+
+func (r *ProjectMemberRepositoryGorm) Remove(projectID, userID string) error {
+	return r.db.Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&ProjectMemberModel{}).Error
+}
@@ -0,0 +1,153 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type ProjectRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) *ProjectRepositoryGorm {
+	return &ProjectRepositoryGorm{db: db}
+}
+
+func (r *ProjectRepositoryGorm) Create(project *model.Project) error {
+	return r.db.Create(toProjectModel(project)).Error
+}
+
+func (r *ProjectRepositoryGorm) FindByID(id string) (*model.Project, error) {
+	var projectModel ProjectModel
+	if err := r.db.First(&projectModel, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return toDomainProject(&projectModel), nil
+}
+
+// FindByUserID - Proyectos propios y compartidos con el usuario
+func (r *ProjectRepositoryGorm) FindByUserID(userID string, includeArchived bool) ([]*model.Project, error) {
+	query := r.db.Where(
+		"user_id = ? OR id IN (SELECT project_id FROM project_members WHERE user_id = ?)",
+		userID, userID,
+	)
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
+	var projectModels []ProjectModel
+	if err := query.Order("position ASC").Order("created_at ASC").Find(&projectModels).Error; err != nil {
+		return nil, err
+	}
+
+	projects := make([]*model.Project, 0, len(projectModels))
+	for i := range projectModels {
+		projects = append(projects, toDomainProject(&projectModels[i]))
+	}
+	return projects, nil
+}
+
+// NextPosition - Posición para un proyecto nuevo (al final de la lista del usuario)
+func (r *ProjectRepositoryGorm) NextPosition(userID string) (int, error) {
+	var maxPosition *int
+	if err := r.db.Model(&ProjectModel{}).
+		Where("user_id = ?", userID).
+		Select("MAX(position)").
+		Scan(&maxPosition).Error; err != nil {
+		return 0, err
+	}
+
+	if maxPosition == nil {
+		return 0, nil
+	}
+	return *maxPosition + 1, nil
+}
+
+func (r *ProjectRepositoryGorm) Update(project *model.Project) error {
+	return r.db.Save(toProjectModel(project)).Error
+}
+
+// Delete - Elimina el proyecto, sus miembros y su catálogo; sus tareas quedan sin
+// proyecto y las que usaban estados o prioridades del proyecto pasan a los del sistema
+func (r *ProjectRepositoryGorm) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", id).Delete(&ProjectMemberModel{}).Error; err != nil {
+			return err
+		}
+		if err := releaseProjectCatalog(tx, id); err != nil {
+			return err
+		}
+		// Unscoped: también las tareas en la papelera, por si se restauran
+		if err := tx.Unscoped().Model(&TaskModel{}).Where("project_id = ?", id).
+			Updates(map[string]interface{}{"project_id": nil, "version": nextVersion()}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&TaskSeriesModel{}).Where("project_id = ?", id).Update("project_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&ProjectModel{}, "id = ?", id).Error
+	})
+}
+
+// ------------------- Helper ---------------------
+
+// releaseProjectCatalog pasa las tareas y series que usan el catálogo del proyecto
+// al estado del sistema de la misma categoría y a prioridad media, y lo elimina
+func releaseProjectCatalog(tx *gorm.DB, projectID string) error {
+	projectStatuses := tx.Model(&TaskStatusModel{}).Select("id").Where("project_id = ?", projectID)
+	projectPriorities := tx.Model(&TaskPriorityModel{}).Select("id").Where("project_id = ?", projectID)
+
+	if err := tx.Unscoped().Model(&TaskModel{}).
+		Where("status_id IN (?)", projectStatuses).
+		Updates(map[string]interface{}{
+			"status_id": gorm.Expr(
+				"CASE (SELECT category FROM task_statuses WHERE task_statuses.id = tasks.status_id) WHEN ? THEN ? ELSE ? END",
+				model.StatusCategoryDone, model.StatusCompleted, model.StatusPending,
+			),
+			"version": nextVersion(),
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Model(&TaskModel{}).Where("priority_id IN (?)", projectPriorities).
+		Updates(map[string]interface{}{"priority_id": model.PriorityMedium, "version": nextVersion()}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&TaskSeriesModel{}).Where("priority_id IN (?)", projectPriorities).
+		Update("priority_id", model.PriorityMedium).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("project_id = ?", projectID).Delete(&TaskStatusModel{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("project_id = ?", projectID).Delete(&TaskPriorityModel{}).Error
+}
+
+func toProjectModel(project *model.Project) *ProjectModel {
+	return &ProjectModel{
+		ID:          project.ID,
+		UserID:      project.UserID,
+		Name:        project.Name,
+		Description: project.Description,
+		Color:       project.Color,
+		Archived:    project.Archived,
+		Position:    project.Position,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
+
+func toDomainProject(pm *ProjectModel) *model.Project {
+	return &model.Project{
+		ID:          pm.ID,
+		UserID:      pm.UserID,
+		Name:        pm.Name,
+		Description: pm.Description,
+		Color:       pm.Color,
+		Archived:    pm.Archived,
+		Position:    pm.Position,
+		CreatedAt:   pm.CreatedAt,
+		UpdatedAt:   pm.UpdatedAt,
+	}
+}
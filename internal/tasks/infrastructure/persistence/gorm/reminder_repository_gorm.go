@@ -0,0 +1,120 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReminderRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) *ReminderRepositoryGorm {
+	return &ReminderRepositoryGorm{db: db}
+}
+
+func (r *ReminderRepositoryGorm) Create(reminder *model.Reminder) error {
+	return r.db.Create(toReminderModel(reminder)).Error
+}
+
+func (r *ReminderRepositoryGorm) FindByID(id string) (*model.Reminder, error) {
+	var reminderModel ReminderModel
+	if err := r.db.First(&reminderModel, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return toDomainReminder(&reminderModel), nil
+}
+
+func (r *ReminderRepositoryGorm) FindByTaskID(taskID string) ([]*model.Reminder, error) {
+	var reminderModels []ReminderModel
+	if err := r.db.Where("task_id = ?", taskID).Order("remind_at ASC").Find(&reminderModels).Error; err != nil {
+		return nil, err
+	}
+	return toDomainReminders(reminderModels), nil
+}
+
+func (r *ReminderRepositoryGorm) Update(reminder *model.Reminder) error {
+	return r.db.Save(toReminderModel(reminder)).Error
+}
+
+func (r *ReminderRepositoryGorm) Delete(id string) error {
+	return r.db.Delete(&ReminderModel{}, "id = ?", id).Error
+}
+
+func (r *ReminderRepositoryGorm) FindDue(now time.Time, limit int) ([]*model.Reminder, error) {
+	var reminderModels []ReminderModel
+	err := r.db.
+		Where("(status = ? AND remind_at <= ?) OR (status = ? AND locked_until < ?)",
+			model.ReminderPending, now, model.ReminderSending, now).
+		Order("remind_at ASC").
+		Limit(limit).
+		Find(&reminderModels).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDomainReminders(reminderModels), nil
+}
+
+// Claim - UPDATE condicional: solo una instancia del scheduler consigue cambiar la fila
+func (r *ReminderRepositoryGorm) Claim(id string, now, lockedUntil time.Time) (bool, error) {
+	result := r.db.Model(&ReminderModel{}).
+		Where("id = ?", id).
+		Where("(status = ? AND remind_at <= ?) OR (status = ? AND locked_until < ?)",
+			model.ReminderPending, now, model.ReminderSending, now).
+		Updates(map[string]interface{}{
+			"status":       model.ReminderSending,
+			"locked_until": lockedUntil,
+			"attempts":     gorm.Expr("attempts + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ------------------- Helper ---------------------
+func toReminderModel(reminder *model.Reminder) *ReminderModel {
+	return &ReminderModel{
+		ID:            reminder.ID,
+		TaskID:        reminder.TaskID,
+		UserID:        reminder.UserID,
+		OffsetMinutes: reminder.OffsetMinutes,
+		RemindAt:      reminder.RemindAt,
+		Channel:       reminder.Channel,
+		Status:        string(reminder.Status),
+		Attempts:      reminder.Attempts,
+		LastError:     reminder.LastError,
+		SentAt:        reminder.SentAt,
+		LockedUntil:   nullableTime(reminder.LockedUntil),
+		CreatedAt:     reminder.CreatedAt,
+		UpdatedAt:     reminder.UpdatedAt,
+	}
+}
+
+func toDomainReminder(rm *ReminderModel) *model.Reminder {
+	return &model.Reminder{
+		ID:            rm.ID,
+		TaskID:        rm.TaskID,
+		UserID:        rm.UserID,
+		OffsetMinutes: rm.OffsetMinutes,
+		RemindAt:      rm.RemindAt,
+		Channel:       rm.Channel,
+		Status:        model.ReminderStatus(rm.Status),
+		Attempts:      rm.Attempts,
+		LastError:     rm.LastError,
+		SentAt:        rm.SentAt,
+		LockedUntil:   derefTime(rm.LockedUntil),
+		CreatedAt:     rm.CreatedAt,
+		UpdatedAt:     rm.UpdatedAt,
+	}
+}
+
+func toDomainReminders(reminderModels []ReminderModel) []*model.Reminder {
+	reminders := make([]*model.Reminder, 0, len(reminderModels))
+	for i := range reminderModels {
+		reminders = append(reminders, toDomainReminder(&reminderModels[i]))
+	}
+	return reminders
+}
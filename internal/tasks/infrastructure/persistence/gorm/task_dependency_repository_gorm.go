@@ -0,0 +1,81 @@
+package gorm
+
+import (
+	"errors"
+	"go-task-easy-list/internal/tasks/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type TaskDependencyRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewTaskDependencyRepository(db *gorm.DB) *TaskDependencyRepositoryGorm {
+	return &TaskDependencyRepositoryGorm{db: db}
+}
+
+func (r *TaskDependencyRepositoryGorm) Create(dependency *model.TaskDependency) error {
+	return r.db.Create(&TaskDependencyModel{
+		BlockerID: dependency.BlockerID,
+		BlockedID: dependency.BlockedID,
+		CreatedAt: dependency.CreatedAt,
+	}).Error
+}
+
+func (r *TaskDependencyRepositoryGorm) Delete(blockerID, blockedID string) error {
+	return r.db.Delete(&TaskDependencyModel{}, "blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Error
+}
+
+func (r *TaskDependencyRepositoryGorm) Find(blockerID, blockedID string) (*model.TaskDependency, error) {
+	var dependencyModel TaskDependencyModel
+	err := r.db.First(&dependencyModel, "blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainTaskDependency(&dependencyModel), nil
+}
+
+func (r *TaskDependencyRepositoryGorm) FindByBlockerIDs(blockerIDs []string) ([]*model.TaskDependency, error) {
+	if len(blockerIDs) == 0 {
+		return []*model.TaskDependency{}, nil
+	}
+	return r.find(r.db.Where("blocker_id IN ?", blockerIDs))
+}
+
+func (r *TaskDependencyRepositoryGorm) FindByBlockedID(blockedID string) ([]*model.TaskDependency, error) {
+	return r.find(r.db.Where("blocked_id = ?", blockedID))
+}
+
+func (r *TaskDependencyRepositoryGorm) FindByTaskIDs(taskIDs []string) ([]*model.TaskDependency, error) {
+	if len(taskIDs) == 0 {
+		return []*model.TaskDependency{}, nil
+	}
+	return r.find(r.db.Where("blocker_id IN ? OR blocked_id IN ?", taskIDs, taskIDs))
+}
+
+// ------------------- Helper ---------------------
+
+func (r *TaskDependencyRepositoryGorm) find(query *gorm.DB) ([]*model.TaskDependency, error) {
+	var dependencyModels []TaskDependencyModel
+	if err := query.Order("created_at ASC").Find(&dependencyModels).Error; err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]*model.TaskDependency, 0, len(dependencyModels))
+	for i := range dependencyModels {
+		dependencies = append(dependencies, toDomainTaskDependency(&dependencyModels[i]))
+	}
+	return dependencies, nil
+}
+
+func toDomainTaskDependency(dm *TaskDependencyModel) *model.TaskDependency {
+	return &model.TaskDependency{
+		BlockerID: dm.BlockerID,
+		BlockedID: dm.BlockedID,
+		CreatedAt: dm.CreatedAt,
+	}
+}
@@ -0,0 +1,85 @@
+package gorm
+
+import (
+	"encoding/json"
+	"go-task-easy-list/internal/tasks/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type TaskEventRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewTaskEventRepository(db *gorm.DB) *TaskEventRepositoryGorm {
+	return &TaskEventRepositoryGorm{db: db}
+}
+
+func (r *TaskEventRepositoryGorm) Create(event *model.TaskEvent) error {
+	eventModel, err := toTaskEventModel(event)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(eventModel).Error
+}
+
+func (r *TaskEventRepositoryGorm) FindByTaskID(taskID string, limit, offset int) ([]*model.TaskEvent, int64, error) {
+	query := r.db.Model(&TaskEventModel{}).Where("task_id = ?", taskID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var eventModels []TaskEventModel
+	err := query.
+		Order("created_at DESC").Order("id DESC").
+		Limit(limit).Offset(offset).
+		Find(&eventModels).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]*model.TaskEvent, 0, len(eventModels))
+	for i := range eventModels {
+		event, err := toDomainTaskEvent(&eventModels[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+	return events, total, nil
+}
+
+// ------------------- Helper ---------------------
+func toTaskEventModel(event *model.TaskEvent) (*TaskEventModel, error) {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return nil, err
+	}
+	return &TaskEventModel{
+		ID:        event.ID,
+		TaskID:    event.TaskID,
+		ActorID:   event.ActorID,
+		Action:    string(event.Action),
+		Changes:   string(changes),
+		RequestID: event.RequestID,
+		CreatedAt: event.CreatedAt,
+	}, nil
+}
+
+func toDomainTaskEvent(em *TaskEventModel) (*model.TaskEvent, error) {
+	var changes map[string]model.FieldChange
+	if err := json.Unmarshal([]byte(em.Changes), &changes); err != nil {
+		return nil, err
+	}
+	return &model.TaskEvent{
+		ID:        em.ID,
+		TaskID:    em.TaskID,
+		ActorID:   em.ActorID,
+		Action:    model.TaskAction(em.Action),
+		Changes:   changes,
+		RequestID: em.RequestID,
+		CreatedAt: em.CreatedAt,
+	}, nil
+}
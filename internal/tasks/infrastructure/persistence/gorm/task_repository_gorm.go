@@ -2,9 +2,13 @@ package gorm
 
 import (
 	"go-task-easy-list/internal/tasks/domain/model"
+	"go-task-easy-list/internal/tasks/domain/repository"
+	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepositoryGorm struct {
@@ -16,25 +20,15 @@ func NewTaskRepository(db *gorm.DB) *TaskRepositoryGorm {
 }
 
 func (r *TaskRepositoryGorm) Create(task *model.Task) error {
-	taskModel := &TaskModel{
-		ID: task.ID,
-		UserID: task.UserID,
-		Title: task.Title,
-		Description: task.Description,
-		StatusID: task.StatusID,
-		PriorityID: task.PriorityID,
-		StartsAt: &task.StartsAt,
-		DueDate: &task.DueDate,
-		CompletedAt: &task.CompletedAt,
-		CreatedAt: task.CreatedAt,
-		UpdatedAt: task.UpdatedAt,
-	}
-
-	if err := r.db.Create(taskModel).Error; err != nil {
-		return err
-	}
+	task.Version = 1
+	taskModel := toTaskModel(task)
 
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(taskModel).Error; err != nil {
+			return err
+		}
+		return upsertSearchIndex(tx, taskModel)
+	})
 }
 
 func (r *TaskRepositoryGorm) FindByUserID(userID string) ([]*model.Task, error) {
@@ -43,24 +37,83 @@ func (r *TaskRepositoryGorm) FindByUserID(userID string) ([]*model.Task, error)
 		return nil, err
 	}
 
-	var tasks []*model.Task
-	for _, tm := range taskModels {
-		task := &model.Task{
-			ID:        tm.ID,
-			UserID:    tm.UserID,
-			Title:     tm.Title,
-			StatusID:  tm.StatusID,
-			PriorityID: tm.PriorityID,
-			Description: tm.Description,
-			StartsAt:  derefTime(tm.StartsAt),
-			DueDate:   derefTime(tm.DueDate),
-			CompletedAt: derefTime(tm.CompletedAt),
-			CreatedAt: tm.CreatedAt,
-			UpdatedAt: tm.UpdatedAt,
+	return toDomainTasks(taskModels), nil
+}
+
+// FindByCriteria - Lista tareas aplicando filtros, orden y paginación.
+// Retorna también el total de tareas que cumplen los filtros (sin paginar).
+func (r *TaskRepositoryGorm) FindByCriteria(filter model.TaskFilter) ([]*model.Task, int64, error) {
+	query := r.db.Model(&TaskModel{})
+
+	if filter.UserID != "" {
+		query = query.Where("tasks.user_id = ?", filter.UserID)
+	}
+	if filter.ProjectID != "" {
+		query = query.Where("tasks.project_id = ?", filter.ProjectID)
+	}
+	if len(filter.StatusIDs) > 0 {
+		query = query.Where("tasks.status_id IN ?", filter.StatusIDs)
+	}
+	if len(filter.PriorityIDs) > 0 {
+		query = query.Where("tasks.priority_id IN ?", filter.PriorityIDs)
+	}
+	if filter.DueFrom != nil {
+		query = query.Where("tasks.due_date >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		query = query.Where("tasks.due_date <= ?", *filter.DueTo)
+	}
+	if filter.HasDueDate != nil {
+		if *filter.HasDueDate {
+			query = query.Where("tasks.due_date IS NOT NULL")
+		} else {
+			query = query.Where("tasks.due_date IS NULL")
 		}
-		tasks = append(tasks, task)
 	}
-	return tasks, nil
+	if filter.CreatedFrom != nil {
+		query = query.Where("tasks.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("tasks.created_at <= ?", *filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		query = query.Where("tasks.updated_at >= ?", *filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		query = query.Where("tasks.updated_at <= ?", *filter.UpdatedTo)
+	}
+	if len(filter.LabelIDs) > 0 {
+		// Las etiquetas son privadas: las de otros miembros del proyecto no filtran
+		query = query.Where(
+			"tasks.id IN (SELECT task_labels.task_id FROM task_labels JOIN labels ON labels.id = task_labels.label_id WHERE task_labels.label_id IN ? AND labels.user_id = ?)",
+			filter.LabelIDs, filter.ViewerID,
+		)
+	}
+	if filter.Text != "" {
+		pattern := "%" + escapeLike(filter.Text) + "%"
+		query = query.Where(
+			"(tasks.title LIKE ? ESCAPE '\\' OR tasks.description LIKE ? ESCAPE '\\')",
+			pattern, pattern,
+		)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.KeysetMode {
+		query = applyKeyset(query, filter)
+	} else {
+		query = applySort(query, filter)
+	}
+
+	var taskModels []TaskModel
+	if err := query.Select("tasks.*").Find(&taskModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return toDomainTasks(taskModels), total, nil
 }
 
 func (r *TaskRepositoryGorm) FindByID(id string) (*model.Task, error) {
@@ -69,49 +122,281 @@ func (r *TaskRepositoryGorm) FindByID(id string) (*model.Task, error) {
 		return nil, err
 	}
 
-	task := &model.Task{
-		ID:        taskModel.ID,
-		UserID:    taskModel.UserID,
-		Title:     taskModel.Title,
-		Description: taskModel.Description,
-		StartsAt:  derefTime(taskModel.StartsAt),
-		DueDate:   derefTime(taskModel.DueDate),
-		CompletedAt: derefTime(taskModel.CompletedAt),
-		CreatedAt: taskModel.CreatedAt,
-		UpdatedAt: taskModel.UpdatedAt,
+	return toDomainTask(&taskModel), nil
+}
+
+func (r *TaskRepositoryGorm) FindByParentID(parentID string) ([]*model.Task, error) {
+	var taskModels []TaskModel
+	if err := r.db.Where("parent_id = ?", parentID).Order("created_at ASC").Find(&taskModels).Error; err != nil {
+		return nil, err
 	}
 
-	return task, nil
+	return toDomainTasks(taskModels), nil
 }
 
-func (r *TaskRepositoryGorm) Update(task *model.Task) error {
-	taskModel := &TaskModel{
-		ID: task.ID,
-		UserID: task.UserID,
-		Title: task.Title,
-		Description: task.Description,
-		StatusID: task.StatusID,
-		PriorityID: task.PriorityID,
-		StartsAt: &task.StartsAt,
-		DueDate: &task.DueDate,
-		CompletedAt: &task.CompletedAt,
-		CreatedAt: task.CreatedAt,
-		UpdatedAt: task.UpdatedAt,
+// FindBySeriesID - Ocurrencias de una serie recurrente, en orden
+func (r *TaskRepositoryGorm) FindBySeriesID(seriesID string) ([]*model.Task, error) {
+	var taskModels []TaskModel
+	if err := r.db.Where("series_id = ?", seriesID).Order("occurrence ASC").Find(&taskModels).Error; err != nil {
+		return nil, err
 	}
 
-	if err := r.db.Save(taskModel).Error; err != nil {
-		return err
-	}	
-	return nil
+	return toDomainTasks(taskModels), nil
 }
 
-func (r *TaskRepositoryGorm) Delete(id string) error {
-	if err := r.db.Delete(&TaskModel{}, "id = ?", id).Error; err != nil {
+// CountProgressByParentIDs - Cuenta subtareas directas (total y completadas) por tarea padre.
+// Las tareas sin subtareas no aparecen en el mapa.
+func (r *TaskRepositoryGorm) CountProgressByParentIDs(parentIDs []string) (map[string]model.TaskProgress, error) {
+	progress := make(map[string]model.TaskProgress)
+	if len(parentIDs) == 0 {
+		return progress, nil
+	}
+
+	var rows []struct {
+		ParentID  string
+		Total     int
+		Completed int
+	}
+	if err := r.db.Model(&TaskModel{}).
+		Select("parent_id, COUNT(*) AS total, SUM(CASE WHEN status_id IN (SELECT id FROM task_statuses WHERE category = ?) THEN 1 ELSE 0 END) AS completed", model.StatusCategoryDone).
+		Where("parent_id IN ?", parentIDs).
+		Group("parent_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		progress[row.ParentID] = model.TaskProgress{Completed: row.Completed, Total: row.Total}
+	}
+	return progress, nil
+}
+
+// Update - UPDATE condicionado a la versión leída (bloqueo optimista)
+func (r *TaskRepositoryGorm) Update(task *model.Task) error {
+	taskModel := toTaskModel(task)
+	taskModel.Version = task.Version + 1
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&TaskModel{}).
+			Where("id = ? AND version = ?", task.ID, task.Version).
+			Select("*").Omit("id", "created_at", "deleted_at", clause.Associations).
+			Updates(taskModel)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrVersionConflict
+		}
+		return upsertSearchIndex(tx, taskModel)
+	})
+	if err != nil {
 		return err
 	}
+
+	task.Version = taskModel.Version
 	return nil
 }
 
+// Delete - Borrado lógico: conserva etiquetas y recordatorios por si se restaura
+func (r *TaskRepositoryGorm) Delete(id string, deletedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&TaskModel{}).Where("id = ?", id).UpdateColumn("deleted_at", deletedAt).Error; err != nil {
+			return err
+		}
+		return deleteSearchIndex(tx, id)
+	})
+}
+
+func (r *TaskRepositoryGorm) FindDeletedByID(id string) (*model.Task, error) {
+	var taskModel TaskModel
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&taskModel, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainTask(&taskModel), nil
+}
+
+func (r *TaskRepositoryGorm) FindDeletedByParentID(parentID string) ([]*model.Task, error) {
+	var taskModels []TaskModel
+	err := r.db.Unscoped().
+		Where("parent_id = ? AND deleted_at IS NOT NULL", parentID).
+		Order("created_at ASC").
+		Find(&taskModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainTasks(taskModels), nil
+}
+
+func (r *TaskRepositoryGorm) FindTrash(userID string, limit, offset int) ([]*model.Task, int64, error) {
+	query := r.db.Unscoped().Model(&TaskModel{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Where("(parent_id IS NULL OR parent_id NOT IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL))")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var taskModels []TaskModel
+	if err := query.Order("deleted_at DESC").Order("id ASC").Limit(limit).Offset(offset).Find(&taskModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return toDomainTasks(taskModels), total, nil
+}
+
+func (r *TaskRepositoryGorm) Restore(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&TaskModel{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		var taskModel TaskModel
+		if err := tx.First(&taskModel, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return upsertSearchIndex(tx, &taskModel)
+	})
+}
+
+// PurgeDeleted - Borrado físico de la papelera vencida, con sus etiquetas, recordatorios y dependencias
+func (r *TaskRepositoryGorm) PurgeDeleted(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().Model(&TaskModel{}).Select("id").Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+
+		if err := tx.Where("task_id IN (?)", expired).Delete(&TaskLabelModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id IN (?)", expired).Delete(&ReminderModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("blocker_id IN (?) OR blocked_id IN (?)", expired, expired).Delete(&TaskDependencyModel{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&TaskModel{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// Search - Búsqueda de texto completo en título y descripción.
+// Usa FTS5 (ranking bm25, snippets resaltados) en SQLite y LIKE en otros motores.
+func (r *TaskRepositoryGorm) Search(userID, query string, limit, offset int) ([]*model.TaskSearchResult, int64, error) {
+	terms := parseSearchTerms(query)
+	if len(terms) == 0 {
+		return []*model.TaskSearchResult{}, 0, nil
+	}
+
+	if isSQLite(r.db) {
+		return r.searchFTS(userID, terms, limit, offset)
+	}
+	return r.searchLike(userID, terms, limit, offset)
+}
+
+type ftsRow struct {
+	TaskID             string
+	Rank               float64
+	TitleHighlight     string
+	DescriptionSnippet string
+}
+
+func (r *TaskRepositoryGorm) searchFTS(userID string, terms []searchTerm, limit, offset int) ([]*model.TaskSearchResult, int64, error) {
+	match := buildMatchExpression(terms)
+
+	var total int64
+	if err := r.db.Raw(
+		"SELECT COUNT(*) FROM tasks_fts WHERE tasks_fts MATCH ? AND user_id = ?",
+		match, userID,
+	).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []ftsRow
+	// El título pesa más que la descripción en el ranking
+	if err := r.db.Raw(`SELECT task_id,
+			bm25(tasks_fts, 10.0, 1.0) AS rank,
+			highlight(tasks_fts, 0, ?, ?) AS title_highlight,
+			`+searchSnippetSQL(1)+` AS description_snippet
+		FROM tasks_fts
+		WHERE tasks_fts MATCH ? AND user_id = ?
+		ORDER BY rank
+		LIMIT ? OFFSET ?`,
+		highlightOpen, highlightClose, match, userID, limit, offset,
+	).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.TaskID)
+	}
+
+	var taskModels []TaskModel
+	if err := r.db.Where("id IN ?", ids).Find(&taskModels).Error; err != nil {
+		return nil, 0, err
+	}
+	tasksByID := make(map[string]*model.Task, len(taskModels))
+	for _, task := range toDomainTasks(taskModels) {
+		tasksByID[task.ID] = task
+	}
+
+	results := make([]*model.TaskSearchResult, 0, len(rows))
+	for _, row := range rows {
+		task, ok := tasksByID[row.TaskID]
+		if !ok {
+			continue
+		}
+		results = append(results, &model.TaskSearchResult{
+			Task:               task,
+			Rank:               row.Rank,
+			TitleHighlight:     row.TitleHighlight,
+			DescriptionSnippet: row.DescriptionSnippet,
+		})
+	}
+	return results, total, nil
+}
+
+func (r *TaskRepositoryGorm) searchLike(userID string, terms []searchTerm, limit, offset int) ([]*model.TaskSearchResult, int64, error) {
+	query := r.db.Model(&TaskModel{}).Where("user_id = ?", userID)
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term.Text) + "%"
+		query = query.Where("(title LIKE ? ESCAPE '\\' OR description LIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+
+	var taskModels []TaskModel
+	if err := query.Find(&taskModels).Error; err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*model.TaskSearchResult, 0, len(taskModels))
+	for _, task := range toDomainTasks(taskModels) {
+		results = append(results, &model.TaskSearchResult{
+			Task:               task,
+			Rank:               likeRank(task.Title, task.Description, terms),
+			TitleHighlight:     highlightText(task.Title, terms),
+			DescriptionSnippet: snippetText(task.Description, terms),
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank < results[j].Rank
+	})
+
+	total := int64(len(results))
+	if offset >= len(results) {
+		return []*model.TaskSearchResult{}, total, nil
+	}
+	end := offset + limit
+	if limit <= 0 || end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], total, nil
+}
+
 func (r *TaskRepositoryGorm) ChangeStatus(taskID string, statusID int) error {
 	if err := r.db.Model(&TaskModel{}).Where("id = ?", taskID).Update("status_id", statusID).Error; err != nil {
 		return err
@@ -127,9 +412,142 @@ func (r *TaskRepositoryGorm) ChangePriority(taskID string, priorityID int) error
 }
 
 // ------------------- Helper ---------------------
+func applySort(query *gorm.DB, filter model.TaskFilter) *gorm.DB {
+	for _, order := range filter.Sort {
+		direction := "ASC"
+		if order.Desc {
+			direction = "DESC"
+		}
+
+		switch order.Field {
+		case model.SortByDueDate:
+			// Las tareas sin vencimiento van al final en ambos sentidos
+			query = query.Order("tasks.due_date IS NULL").Order("tasks.due_date " + direction)
+		case model.SortByPriority:
+			query = query.
+				Joins("JOIN task_priorities ON task_priorities.id = tasks.priority_id").
+				Order("task_priorities.level " + direction)
+		case model.SortByCreatedAt:
+			query = query.Order("tasks.created_at " + direction)
+		}
+	}
+	// Desempate estable para que la paginación no repita ni salte filas
+	query = query.Order("tasks.id ASC")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	return query
+}
+
+// applyKeyset pagina sobre el orden estable (created_at DESC, id DESC).
+// Hacia atrás se invierte el orden; el llamador debe revertir el resultado.
+func applyKeyset(query *gorm.DB, filter model.TaskFilter) *gorm.DB {
+	cursor := filter.Cursor
+
+	if cursor != nil && cursor.Backward {
+		query = query.
+			Where("(tasks.created_at > ? OR (tasks.created_at = ? AND tasks.id > ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID).
+			Order("tasks.created_at ASC").Order("tasks.id ASC")
+	} else {
+		if cursor != nil {
+			query = query.Where("(tasks.created_at < ? OR (tasks.created_at = ? AND tasks.id < ?))", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+		}
+		query = query.Order("tasks.created_at DESC").Order("tasks.id DESC")
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	return query
+}
+
+func toTaskModel(task *model.Task) *TaskModel {
+	return &TaskModel{
+		ID: task.ID,
+		UserID: task.UserID,
+		ParentID: nullableString(task.ParentID),
+		ProjectID: nullableString(task.ProjectID),
+		Title: task.Title,
+		Description: task.Description,
+		StatusID: task.StatusID,
+		PriorityID: task.PriorityID,
+		StartsAt: nullableTime(task.StartsAt),
+		DueDate: nullableTime(task.DueDate),
+		CompletedAt: nullableTime(task.CompletedAt),
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+		Version: task.Version,
+		RecurrenceRule: task.RecurrenceRule,
+		SeriesID: nullableString(task.SeriesID),
+		Occurrence: task.Occurrence,
+		DeletedAt: gorm.DeletedAt{Time: task.DeletedAt, Valid: !task.DeletedAt.IsZero()},
+	}
+}
+
+func toDomainTask(tm *TaskModel) *model.Task {
+	return &model.Task{
+		ID:        tm.ID,
+		UserID:    tm.UserID,
+		ParentID:  derefString(tm.ParentID),
+		ProjectID: derefString(tm.ProjectID),
+		Title:     tm.Title,
+		Description: tm.Description,
+		StatusID:  tm.StatusID,
+		PriorityID: tm.PriorityID,
+		StartsAt:  derefTime(tm.StartsAt),
+		DueDate:   derefTime(tm.DueDate),
+		CompletedAt: derefTime(tm.CompletedAt),
+		CreatedAt: tm.CreatedAt,
+		UpdatedAt: tm.UpdatedAt,
+		Version:   tm.Version,
+		RecurrenceRule: tm.RecurrenceRule,
+		SeriesID:  derefString(tm.SeriesID),
+		Occurrence: tm.Occurrence,
+		DeletedAt: tm.DeletedAt.Time,
+	}
+}
+
+// nextVersion incrementa la versión en los UPDATE masivos de tareas, para que los
+// clientes con una copia anterior reciban el conflicto al guardarla
+func nextVersion() clause.Expr {
+	return gorm.Expr("version + 1")
+}
+
+func toDomainTasks(taskModels []TaskModel) []*model.Task {
+	tasks := make([]*model.Task, 0, len(taskModels))
+	for i := range taskModels {
+		tasks = append(tasks, toDomainTask(&taskModels[i]))
+	}
+	return tasks
+}
+
 func derefTime(t *time.Time) time.Time {
 	if t != nil {
 		return *t
 	}
 	return time.Time{}
-}
\ No newline at end of file
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func derefString(s *string) string {
+	if s != nil {
+		return *s
+	}
+	return ""
+}
+
+// escapeLike escapa los comodines de LIKE para buscar el texto literal
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
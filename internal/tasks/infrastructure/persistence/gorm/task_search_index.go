@@ -0,0 +1,226 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+	snippetTokens  = 12
+)
+
+// SetupTaskSearchIndex crea el índice FTS5 de tareas (solo SQLite) y lo
+// completa con las tareas que aún no estén indexadas.
+func SetupTaskSearchIndex(db *gorm.DB) error {
+	if !isSQLite(db) {
+		return nil
+	}
+
+	if err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+		title,
+		description,
+		task_id UNINDEXED,
+		user_id UNINDEXED,
+		tokenize = 'unicode61 remove_diacritics 2'
+	)`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`INSERT INTO tasks_fts (title, description, task_id, user_id)
+		SELECT title, COALESCE(description, ''), id, user_id FROM tasks
+		WHERE deleted_at IS NULL AND id NOT IN (SELECT task_id FROM tasks_fts)`).Error
+}
+
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+func upsertSearchIndex(tx *gorm.DB, taskModel *TaskModel) error {
+	if !isSQLite(tx) {
+		return nil
+	}
+
+	if err := deleteSearchIndex(tx, taskModel.ID); err != nil {
+		return err
+	}
+	return tx.Exec(
+		"INSERT INTO tasks_fts (title, description, task_id, user_id) VALUES (?, ?, ?, ?)",
+		taskModel.Title, taskModel.Description, taskModel.ID, taskModel.UserID,
+	).Error
+}
+
+func deleteSearchIndex(tx *gorm.DB, taskID string) error {
+	if !isSQLite(tx) {
+		return nil
+	}
+	return tx.Exec("DELETE FROM tasks_fts WHERE task_id = ?", taskID).Error
+}
+
+// ------------------- Query parsing ---------------------
+
+// searchTerm - Término de búsqueda: palabra, prefijo (palabra*) o frase ("...")
+type searchTerm struct {
+	Text   string
+	Prefix bool
+	Phrase bool
+}
+
+// parseSearchTerms separa la consulta del usuario en términos.
+// Soporta frases entre comillas dobles y prefijos terminados en "*".
+func parseSearchTerms(query string) []searchTerm {
+	var terms []searchTerm
+
+	for len(query) > 0 {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		if query == "" {
+			break
+		}
+
+		if query[0] == '"' {
+			end := strings.IndexByte(query[1:], '"')
+			var phrase string
+			if end == -1 {
+				phrase, query = query[1:], ""
+			} else {
+				phrase, query = query[1:end+1], query[end+2:]
+			}
+			if words := strings.Fields(phrase); len(words) > 0 {
+				terms = append(terms, searchTerm{Text: strings.Join(words, " "), Phrase: true})
+			}
+			continue
+		}
+
+		end := strings.IndexFunc(query, unicode.IsSpace)
+		if end == -1 {
+			end = len(query)
+		}
+		word := query[:end]
+		query = query[end:]
+
+		prefix := strings.HasSuffix(word, "*")
+		word = strings.Trim(word, `*"`)
+		if word != "" {
+			terms = append(terms, searchTerm{Text: word, Prefix: prefix})
+		}
+	}
+
+	return terms
+}
+
+// buildMatchExpression traduce los términos a la sintaxis MATCH de FTS5,
+// entrecomillando cada término para que la entrada del usuario no pueda
+// inyectar operadores (AND, OR, NEAR, columnas...).
+func buildMatchExpression(terms []searchTerm) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted := `"` + strings.ReplaceAll(term.Text, `"`, `""`) + `"`
+		if term.Prefix {
+			quoted += "*"
+		}
+		parts = append(parts, quoted)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ------------------- Fallback (sin FTS5) ---------------------
+
+// likeRank calcula una relevancia aproximada para el fallback con LIKE:
+// las coincidencias en el título pesan más que en la descripción.
+// Se devuelve negativa para mantener la convención de bm25 (menor = mejor).
+func likeRank(title, description string, terms []searchTerm) float64 {
+	title, description = strings.ToLower(title), strings.ToLower(description)
+	score := 0.0
+	for _, term := range terms {
+		text := strings.ToLower(term.Text)
+		score += 10 * float64(strings.Count(title, text))
+		score += float64(strings.Count(description, text))
+	}
+	return -score
+}
+
+// highlightText marca todas las apariciones de los términos en el texto
+func highlightText(text string, terms []searchTerm) string {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Algunos caracteres cambian de tamaño al pasar a minúsculas; no se resalta
+		return text
+	}
+
+	marks := make([]bool, len(text))
+	for _, term := range terms {
+		needle := strings.ToLower(term.Text)
+		if needle == "" {
+			continue
+		}
+		for start := 0; ; {
+			idx := strings.Index(lower[start:], needle)
+			if idx == -1 {
+				break
+			}
+			for i := start + idx; i < start+idx+len(needle); i++ {
+				marks[i] = true
+			}
+			start += idx + len(needle)
+		}
+	}
+
+	var b strings.Builder
+	open := false
+	for i := 0; i < len(text); i++ {
+		if marks[i] && !open {
+			b.WriteString(highlightOpen)
+			open = true
+		} else if !marks[i] && open {
+			b.WriteString(highlightClose)
+			open = false
+		}
+		b.WriteByte(text[i])
+	}
+	if open {
+		b.WriteString(highlightClose)
+	}
+	return b.String()
+}
+
+// snippetText recorta el texto alrededor de la primera coincidencia y la resalta
+func snippetText(text string, terms []searchTerm) string {
+	words := strings.Fields(text)
+	if len(words) <= snippetTokens {
+		return highlightText(text, terms)
+	}
+
+	first := 0
+	for i, word := range words {
+		if highlightText(word, terms) != word {
+			first = i
+			break
+		}
+	}
+
+	start := first - snippetTokens/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetTokens
+	if end > len(words) {
+		end, start = len(words), len(words)-snippetTokens
+	}
+
+	snippet := highlightText(strings.Join(words[start:end], " "), terms)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(words) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+func searchSnippetSQL(column int) string {
+	return fmt.Sprintf("snippet(tasks_fts, %d, '%s', '%s', '…', %d)", column, highlightOpen, highlightClose, snippetTokens)
+}
@@ -0,0 +1,70 @@
+package gorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchTerms(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		terms []searchTerm
+		match string
+	}{
+		{
+			name:  "palabras",
+			query: "  reunión   equipo ",
+			terms: []searchTerm{{Text: "reunión"}, {Text: "equipo"}},
+			match: `"reunión" "equipo"`,
+		},
+		{
+			name:  "frase y prefijo",
+			query: `"plan  de   ventas" inform*`,
+			terms: []searchTerm{{Text: "plan de ventas", Phrase: true}, {Text: "inform", Prefix: true}},
+			match: `"plan de ventas" "inform"*`,
+		},
+		{
+			name:  "frase sin cerrar",
+			query: `"sin cerrar`,
+			terms: []searchTerm{{Text: "sin cerrar", Phrase: true}},
+			match: `"sin cerrar"`,
+		},
+		{
+			name:  "operadores FTS5 como texto",
+			query: "title:x OR NEAR(a b)",
+			terms: []searchTerm{{Text: "title:x"}, {Text: "OR"}, {Text: "NEAR(a"}, {Text: "b)"}},
+			match: `"title:x" "OR" "NEAR(a" "b)"`,
+		},
+		{
+			name:  "comillas dentro de una palabra",
+			query: `a"b`,
+			terms: []searchTerm{{Text: `a"b`}},
+			match: `"a""b"`,
+		},
+		{
+			name:  "solo comodines y comillas",
+			query: `* "" "  "`,
+			terms: nil,
+			match: "",
+		},
+		{
+			name:  "vacía",
+			query: "   ",
+			terms: nil,
+			match: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := parseSearchTerms(tt.query)
+			if !reflect.DeepEqual(terms, tt.terms) {
+				t.Fatalf("parseSearchTerms(%q) = %+v, se esperaba %+v", tt.query, terms, tt.terms)
+			}
+			if match := buildMatchExpression(terms); match != tt.match {
+				t.Errorf("buildMatchExpression = %s, se esperaba %s", match, tt.match)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaskSeriesRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewTaskSeriesRepository(db *gorm.DB) *TaskSeriesRepositoryGorm {
+	return &TaskSeriesRepositoryGorm{db: db}
+}
+
+func (r *TaskSeriesRepositoryGorm) Create(series *model.TaskSeries) error {
+	return r.db.Create(toTaskSeriesModel(series)).Error
+}
+
+func (r *TaskSeriesRepositoryGorm) FindByID(id string) (*model.TaskSeries, error) {
+	var seriesModel TaskSeriesModel
+	if err := r.db.First(&seriesModel, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return toDomainTaskSeries(&seriesModel), nil
+}
+
+func (r *TaskSeriesRepositoryGorm) Update(series *model.TaskSeries) error {
+	return r.db.Save(toTaskSeriesModel(series)).Error
+}
+
+// Delete - Elimina la plantilla; las ocurrencias existentes se conservan como tareas normales
+func (r *TaskSeriesRepositoryGorm) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&TaskModel{}).Where("series_id = ?", id).
+			Updates(map[string]interface{}{"series_id": nil, "occurrence": 0, "recurrence_rule": "", "version": nextVersion()}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&TaskSeriesModel{}, "id = ?", id).Error
+	})
+}
+
+// ------------------- Helper ---------------------
+func toTaskSeriesModel(series *model.TaskSeries) *TaskSeriesModel {
+	return &TaskSeriesModel{
+		ID:             series.ID,
+		UserID:         series.UserID,
+		ProjectID:      nullableString(series.ProjectID),
+		Title:          series.Title,
+		Description:    series.Description,
+		PriorityID:     series.PriorityID,
+		RecurrenceRule: series.RecurrenceRule,
+		StartsAt:       nullableTime(series.StartsAt),
+		DueDate:        nullableTime(series.DueDate),
+		BaseOccurrence: series.BaseOccurrence,
+		CreatedAt:      series.CreatedAt,
+		UpdatedAt:      series.UpdatedAt,
+	}
+}
+
+func toDomainTaskSeries(sm *TaskSeriesModel) *model.TaskSeries {
+	return &model.TaskSeries{
+		ID:             sm.ID,
+		UserID:         sm.UserID,
+		ProjectID:      derefString(sm.ProjectID),
+		Title:          sm.Title,
+		Description:    sm.Description,
+		PriorityID:     sm.PriorityID,
+		RecurrenceRule: sm.RecurrenceRule,
+		StartsAt:       derefTime(sm.StartsAt),
+		DueDate:        derefTime(sm.DueDate),
+		BaseOccurrence: sm.BaseOccurrence,
+		CreatedAt:      sm.CreatedAt,
+		UpdatedAt:      sm.UpdatedAt,
+	}
+}
+
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,33 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type TransactorGorm struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *TransactorGorm {
+	return &TransactorGorm{db: db}
+}
+
+// WithinTransaction crea los repositorios sobre la transacción; las transacciones
+// internas de cada repositorio pasan a ser savepoints de esta
+func (t *TransactorGorm) WithinTransaction(fn func(repos repository.TaskRepositories) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(repository.TaskRepositories{
+			Tasks:        NewTaskRepository(tx),
+			Labels:       NewLabelRepository(tx),
+			Projects:     NewProjectRepository(tx),
+			Members:      NewProjectMemberRepository(tx),
+			Series:       NewTaskSeriesRepository(tx),
+			Reminders:    NewReminderRepository(tx),
+			Catalog:      NewCatalogRepository(tx),
+			Events:       NewTaskEventRepository(tx),
+			Dependencies: NewTaskDependencyRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package gorm
+
+import (
+	"go-task-easy-list/internal/tasks/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+// UserDirectoryGorm lee la tabla users (propiedad del módulo auth) solo para consultas,
+// sin depender de los paquetes de auth.
+type UserDirectoryGorm struct {
+	db *gorm.DB
+}
+
+func NewUserDirectory(db *gorm.DB) *UserDirectoryGorm {
+	return &UserDirectoryGorm{db: db}
+}
+
+func (r *UserDirectoryGorm) FindByEmail(email string) (*repository.UserSummary, error) {
+	var user repository.UserSummary
+	if err := r.db.Table("users").
+		Select("id, email, name").
+		Where("email = ? AND is_active = ?", email, true).
+		Take(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserDirectoryGorm) FindByIDs(ids []string) (map[string]*repository.UserSummary, error) {
+	users := make(map[string]*repository.UserSummary, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	var rows []repository.UserSummary
+	if err := r.db.Table("users").Select("id, email, name").Where("id IN ?", ids).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		users[rows[i].ID] = &rows[i]
+	}
+	return users, nil
+}
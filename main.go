@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-task-easy-list/config"
 	"go-task-easy-list/internal/shared/infrastructure"
+	sharedMiddleware "go-task-easy-list/internal/shared/infrastructure/middleware"
 	"log"
 	"net/http"
 	"os"
@@ -29,9 +30,11 @@ func main() {
 	log.Println("Base de datos conectada")
 
 	// Dependency Injection Container
-	container := infrastructure.NewContainer(db, cfg.JWTSecret)
+	container := infrastructure.NewContainer(db, cfg)
 
 	r := chi.NewRouter()
+	r.Use(sharedMiddleware.RequestID) // X-Request-Id, usado también en el historial de tareas
+	// r.Use(middleware.RealIP)  // Habilitar detrás de un proxy para registrar la IP real de cada sesión
 	// r.Use(middleware.Logger)  // Habilitar si se desea logging de solicitudes
 	// r.Use(middleware.Recoverer)  // Habilitar para recuperación de pánicos y evitar caídas del servidor
 
@@ -49,15 +52,25 @@ func main() {
 		Handler: r,
 	}
 
-	go gracefulShutdown(server)
+	// Scheduler de recordatorios y demás procesos en segundo plano
+	container.StartWorkers()
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		gracefulShutdown(server, container)
+		close(shutdownDone)
+	}()
 
 	log.Printf("Servidor escuchando en http://localhost%s\n", addr)
 	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatal("Error del servidor:", err)
 	}
+
+	// Esperar a que terminen el apagado del servidor y de los workers
+	<-shutdownDone
 }
 
-func gracefulShutdown(server *http.Server) {
+func gracefulShutdown(server *http.Server, container *infrastructure.Container) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	
@@ -70,6 +83,11 @@ func gracefulShutdown(server *http.Server) {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatal("Error al apagar servidor:", err)
 	}
+
+	// Con el servidor cerrado se detienen los workers; un envío en curso se retoma al reiniciar
+	if err := container.StopWorkers(ctx); err != nil {
+		log.Println("Error deteniendo procesos en segundo plano:", err)
+	}
 	
 	log.Println("Servidor detenido correctamente")
 }
\ No newline at end of file
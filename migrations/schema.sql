@@ -13,64 +13,132 @@ CREATE TABLE users (
 CREATE TABLE sessions (
     id              TEXT PRIMARY KEY,
     user_id         TEXT NOT NULL,
-    refresh_token   TEXT NOT NULL,
+    refresh_token_hash TEXT NOT NULL,   -- HMAC-SHA256 del refresh token; nunca el token en claro
+    device_name     TEXT,
+    user_agent      TEXT,
+    ip_address      TEXT,
+    last_used_at    TIMESTAMP,          -- último login o renovación del refresh token
     expires_at      TIMESTAMP NOT NULL,
     created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
 CREATE INDEX idx_sessions_user_id ON sessions(user_id);
-CREATE INDEX idx_sessions_refresh_token ON sessions(refresh_token);
+CREATE UNIQUE INDEX idx_sessions_refresh_token_hash ON sessions(refresh_token_hash);
+
+-- Refresh tokens ya rotados de cada sesión: si se vuelven a presentar se revoca la sesión
+CREATE TABLE rotated_refresh_tokens (
+    token_hash  TEXT PRIMARY KEY,
+    session_id  TEXT NOT NULL,
+    expires_at  TIMESTAMP NOT NULL,
+    rotated_at  TIMESTAMP NOT NULL,
+    FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_rotated_refresh_tokens_session_id ON rotated_refresh_tokens(session_id);
+CREATE INDEX idx_rotated_refresh_tokens_expires_at ON rotated_refresh_tokens(expires_at);
 
 -- ✅ TASKS CONTEXT
 
 -- Tabla de catálogo: Estados de tareas
+-- Los del sistema tienen code y no tienen dueño; los personalizados pertenecen
+-- a un usuario (tareas sin proyecto) o a un proyecto (sus tareas)
 CREATE TABLE task_statuses (
-    id          INTEGER PRIMARY KEY,
-    code        TEXT UNIQUE NOT NULL,       -- PENDING, IN_PROGRESS, COMPLETED
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    code        TEXT UNIQUE,                -- PENDING, IN_PROGRESS, COMPLETED (NULL en personalizados)
     name        TEXT NOT NULL,              -- "Pendiente", "En Progreso", "Completada"
     description TEXT,
+    category    TEXT NOT NULL DEFAULT 'open', -- open | done
+    user_id     TEXT,                       -- creador (NULL = sistema)
+    project_id  TEXT,                       -- proyecto al que pertenece (NULL = del usuario)
     created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE INDEX idx_task_statuses_user_id ON task_statuses(user_id);
+CREATE INDEX idx_task_statuses_project_id ON task_statuses(project_id);
+
 -- Datos iniciales de estados
-INSERT INTO task_statuses (id, code, name, description) VALUES
-(1, 'PENDING', 'Pendiente', 'Tarea pendiente por iniciar'),
-(2, 'IN_PROGRESS', 'En Progreso', 'Tarea en proceso'),
-(3, 'COMPLETED', 'Completada', 'Tarea finalizada');
+INSERT INTO task_statuses (id, code, name, description, category) VALUES
+(1, 'PENDING', 'Pendiente', 'Tarea pendiente por iniciar', 'open'),
+(2, 'IN_PROGRESS', 'En Progreso', 'Tarea en proceso', 'open'),
+(3, 'COMPLETED', 'Completada', 'Tarea finalizada', 'done');
 
 -- Tabla de catálogo: Prioridades
 CREATE TABLE task_priorities (
-    id          INTEGER PRIMARY KEY,
-    code        TEXT UNIQUE NOT NULL,       -- LOW, MEDIUM, HIGH
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    code        TEXT UNIQUE,                -- LOW, MEDIUM, HIGH (NULL en personalizadas)
     name        TEXT NOT NULL,              -- "Baja", "Media", "Alta"
     level       INTEGER NOT NULL,           -- 1=LOW, 2=MEDIUM, 3=HIGH (para ORDER BY)
+    user_id     TEXT,                       -- creador (NULL = sistema)
+    project_id  TEXT,                       -- proyecto al que pertenece (NULL = del usuario)
     created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE INDEX idx_task_priorities_user_id ON task_priorities(user_id);
+CREATE INDEX idx_task_priorities_project_id ON task_priorities(project_id);
+
 -- Datos iniciales de prioridades
 INSERT INTO task_priorities (id, code, name, level) VALUES
 (1, 'LOW', 'Baja', 1),
 (2, 'MEDIUM', 'Media', 2),
 (3, 'HIGH', 'Alta', 3);
 
+-- Proyectos / listas para agrupar tareas
+CREATE TABLE projects (
+    id          TEXT PRIMARY KEY,           -- UUID
+    user_id     TEXT NOT NULL,              -- FK → users
+    name        TEXT NOT NULL,
+    description TEXT,
+    color       TEXT NOT NULL,              -- #RRGGBB
+    archived    BOOLEAN NOT NULL DEFAULT FALSE,
+    position    INTEGER NOT NULL DEFAULT 0, -- orden en la lista del usuario
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_projects_user_id ON projects(user_id);
+
+-- Miembros de proyectos compartidos (el creador es owner implícito)
+CREATE TABLE project_members (
+    project_id  TEXT NOT NULL,              -- FK → projects
+    user_id     TEXT NOT NULL,              -- FK → users
+    role        TEXT NOT NULL,              -- owner | editor | viewer
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (project_id, user_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
+
 -- Tabla principal: Tareas
 CREATE TABLE tasks (
     id              TEXT PRIMARY KEY,           -- UUID
     user_id         TEXT NOT NULL,              -- FK → users
+    parent_id       TEXT,                       -- tarea padre (subtareas), NULL = raíz
+    project_id      TEXT,                       -- FK → projects, NULL = sin proyecto
     title           TEXT NOT NULL,
     description     TEXT,
     status_id       INTEGER NOT NULL DEFAULT 1, -- FK → task_statuses (default: PENDING)
     priority_id     INTEGER NOT NULL DEFAULT 2, -- FK → task_priorities (default: MEDIUM)
-    starts_at       TIMESTAMP,
-    due_date        TIMESTAMP,
-    completed_at    TIMESTAMP,
+    starts_at       TIMESTAMP,                  -- NULL = sin fecha de inicio
+    due_date        TIMESTAMP,                  -- NULL = sin vencimiento
+    completed_at    TIMESTAMP,                  -- NULL = sin completar
     created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    version         INTEGER NOT NULL DEFAULT 1, -- bloqueo optimista (ETag / If-Match)
+    deleted_at      TIMESTAMP,                  -- papelera (borrado lógico), NULL = activa
+    recurrence_rule TEXT,                       -- RRULE de la serie (copia)
+    series_id       TEXT,                       -- FK → task_series, NULL = no recurrente
+    occurrence      INTEGER NOT NULL DEFAULT 0, -- número de ocurrencia dentro de la serie
     
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
     FOREIGN KEY (status_id) REFERENCES task_statuses(id),
-    FOREIGN KEY (priority_id) REFERENCES task_priorities(id)
+    FOREIGN KEY (priority_id) REFERENCES task_priorities(id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
 );
 
 -- Índices optimizados
@@ -80,6 +148,129 @@ CREATE INDEX idx_tasks_priority_id ON tasks(priority_id);
 CREATE INDEX idx_tasks_due_date ON tasks(due_date);
 CREATE INDEX idx_tasks_user_status ON tasks(user_id, status_id);
 CREATE INDEX idx_tasks_user_priority ON tasks(user_id, priority_id);
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+CREATE INDEX idx_tasks_project_id ON tasks(project_id);
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at);
+CREATE UNIQUE INDEX idx_tasks_series_occurrence ON tasks(series_id, occurrence);
+
+-- Plantillas de tareas recurrentes (cada ocurrencia es una fila de tasks)
+CREATE TABLE task_series (
+    id              TEXT PRIMARY KEY,           -- UUID
+    user_id         TEXT NOT NULL,              -- FK → users
+    project_id      TEXT,                       -- FK → projects
+    title           TEXT NOT NULL,
+    description     TEXT,
+    priority_id     INTEGER NOT NULL,
+    recurrence_rule TEXT NOT NULL,              -- RRULE (RFC 5545)
+    starts_at       TIMESTAMP,                  -- DTSTART: fechas de la ocurrencia base
+    due_date        TIMESTAMP,
+    base_occurrence INTEGER NOT NULL DEFAULT 1, -- número de la ocurrencia que cae en DTSTART
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_task_series_user_id ON task_series(user_id);
+
+-- Etiquetas del usuario
+CREATE TABLE labels (
+    id          TEXT PRIMARY KEY,           -- UUID
+    user_id     TEXT NOT NULL,              -- FK → users
+    name        TEXT NOT NULL,
+    color       TEXT NOT NULL,              -- #RRGGBB
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_labels_user_name ON labels(user_id, name);
+
+-- Tabla intermedia tareas <-> etiquetas
+CREATE TABLE task_labels (
+    task_id     TEXT NOT NULL,
+    label_id    TEXT NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (task_id, label_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_labels_label_id ON task_labels(label_id);
+
+-- Dependencias entre tareas: blocker_id bloquea a blocked_id (grafo acíclico)
+CREATE TABLE task_dependencies (
+    blocker_id  TEXT NOT NULL,
+    blocked_id  TEXT NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (blocker_id, blocked_id),
+    FOREIGN KEY (blocker_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_dependencies_blocked_id ON task_dependencies(blocked_id);
+
+-- Recordatorios: absolutos (remind_at) o relativos al vencimiento (offset_minutes).
+-- status + locked_until permiten retomar envíos tras un reinicio sin duplicarlos
+CREATE TABLE reminders (
+    id              TEXT PRIMARY KEY,
+    task_id         TEXT NOT NULL,
+    user_id         TEXT NOT NULL,
+    offset_minutes  INTEGER,
+    remind_at       TIMESTAMP NOT NULL,
+    channel         TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    sent_at         TIMESTAMP,
+    locked_until    TIMESTAMP,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_reminders_task_id ON reminders(task_id);
+CREATE INDEX idx_reminders_user_id ON reminders(user_id);
+CREATE INDEX idx_reminders_due ON reminders(status, remind_at);
+
+-- Historial de cambios de tareas (solo inserción). Sin FOREIGN KEY a tasks:
+-- los eventos se conservan aunque la tarea se elimine.
+CREATE TABLE task_events (
+    id          TEXT PRIMARY KEY,
+    task_id     TEXT NOT NULL,
+    actor_id    TEXT NOT NULL,
+    action      TEXT NOT NULL,  -- created, updated, status_changed, priority_changed, moved, deleted
+    changes     TEXT NOT NULL,  -- JSON {"campo": {"before": ..., "after": ...}}
+    request_id  TEXT,
+    created_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_task_events_task ON task_events(task_id, created_at);
+CREATE INDEX idx_task_events_actor_id ON task_events(actor_id);
+CREATE INDEX idx_task_events_request_id ON task_events(request_id);
+
+-- Respuestas guardadas de los POST con Idempotency-Key (por usuario; vacío sin autenticación)
+CREATE TABLE idempotency_keys (
+    user_id         TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    fingerprint     TEXT NOT NULL,              -- hash de método, ruta y cuerpo
+    status_code     INTEGER NOT NULL DEFAULT 0, -- 0 = petición en curso
+    headers         TEXT,                       -- JSON
+    body            BLOB,
+    locked_until    TIMESTAMP NOT NULL,
+    expires_at      TIMESTAMP NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
 
 -- Vista opcional para queries más simples (JOIN automático)
 CREATE VIEW v_tasks_detailed AS
@@ -99,10 +290,11 @@ SELECT
     t.created_at,
     t.updated_at,
     CASE 
-        WHEN t.due_date < datetime('now') AND t.status_id != 3 
+        WHEN t.due_date < datetime('now') AND ts.category != 'done' 
         THEN 1 
         ELSE 0 
     END as is_overdue
 FROM tasks t
 INNER JOIN task_statuses ts ON t.status_id = ts.id
-INNER JOIN task_priorities tp ON t.priority_id = tp.id;
\ No newline at end of file
+INNER JOIN task_priorities tp ON t.priority_id = tp.id
+WHERE t.deleted_at IS NULL;
\ No newline at end of file